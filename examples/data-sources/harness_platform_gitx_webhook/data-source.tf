@@ -1,6 +1,6 @@
 //Create Project level webhook
 resource "harness_platform_gitx_webhook" "test" {
-  identifier = "webhook Identifier"
+  identifier = "webhook_identifier"
   name       = "webhook name"
   project_id = "projectIdentifier"
   org_id     = "orgIdentifier"
@@ -8,13 +8,13 @@ resource "harness_platform_gitx_webhook" "test" {
 
 //Create Org level webhook
 resource "harness_platform_gitx_webhook" "test" {
-  identifier = "webhook Identifier"
+  identifier = "webhook_identifier"
   name       = "webhook name"
   org_id     = "orgIdentifier"
 }
 
 //Create Account level webhook
 resource "harness_platform_gitx_webhook" "test" {
-  identifier = "webhook Identifier"
+  identifier = "webhook_identifier"
   name       = "webhook name"
 }
\ No newline at end of file
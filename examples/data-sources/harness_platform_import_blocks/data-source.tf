@@ -0,0 +1,18 @@
+data "harness_platform_import_blocks" "example" {
+  org_id     = "org_id"
+  project_id = "project_id"
+  resource_types = [
+    "harness_platform_connector_github",
+    "harness_platform_secret_text",
+    "harness_platform_service",
+    "harness_platform_environment",
+    "harness_platform_pipeline",
+  ]
+}
+
+# Write the import blocks to a file, then run `terraform plan -generate-config-out=generated.tf`
+# in that directory to generate the configuration of the imported resources.
+resource "local_file" "imports" {
+  filename = "${path.module}/imports/imports.tf"
+  content  = data.harness_platform_import_blocks.example.content
+}
@@ -0,0 +1,6 @@
+data "harness_platform_overlay_input_set" "example" {
+  identifier  = "identifier"
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+}
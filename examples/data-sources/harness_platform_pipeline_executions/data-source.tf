@@ -0,0 +1,22 @@
+# Last 10 failed executions of the pipeline triggered by a webhook or a cron
+data "harness_platform_pipeline_executions" "example" {
+  org_id        = "org_id"
+  project_id    = "project_id"
+  pipeline_id   = "pipeline_id"
+  status        = ["Failed", "Errored"]
+  trigger_types = ["WEBHOOK", "SCHEDULER_CRON"]
+  limit         = 10
+}
+
+# Executions of the main branch started since a given time, in milliseconds
+data "harness_platform_pipeline_executions" "main" {
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+  branch      = "main"
+  start_time  = 1700000000000
+}
+
+output "last_failure" {
+  value = try(data.harness_platform_pipeline_executions.example.executions[0].execution_id, null)
+}
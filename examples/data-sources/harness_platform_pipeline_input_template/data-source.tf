@@ -0,0 +1,15 @@
+data "harness_platform_pipeline_input_template" "example" {
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+}
+
+# Inputs without a default value, which the input sets must provide
+output "required_inputs" {
+  value = [for input in data.harness_platform_pipeline_input_template.example.inputs : input.path if input.required]
+}
+
+# Default values of the inputs, keyed by their path in the template
+output "defaults" {
+  value = { for input in data.harness_platform_pipeline_input_template.example.inputs : input.path => input.default if !input.required }
+}
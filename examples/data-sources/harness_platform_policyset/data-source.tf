@@ -1,5 +1,5 @@
 data "harness_platform_policyset" "test" {
-  identifier = "harness_platform_policyset.test.identifier"
+  identifier = "policyset_identifier"
   name       = "harness_platform_policyset.test.name"
   action     = "onrun"
   type       = "pipeline"
@@ -0,0 +1,16 @@
+ephemeral "harness_platform_secret_text" "example" {
+  identifier = "identifier"
+  org_id     = "org_id"
+  project_id = "project_id"
+}
+
+# Ephemeral values can only be used in write-only arguments, provider blocks and other ephemeral resources.
+resource "harness_platform_secret_text" "copy" {
+  identifier = "copy"
+  name       = "copy"
+
+  secret_manager_identifier = ephemeral.harness_platform_secret_text.example.secret_manager_identifier
+  value_type                = "Reference"
+  value_wo                  = ephemeral.harness_platform_secret_text.example.value
+  value_wo_version          = 1
+}
@@ -0,0 +1,11 @@
+# The token is minted for the run and revoked when Terraform no longer needs it.
+ephemeral "harness_platform_token" "service_account" {
+  apikey_id   = "apikey_id"
+  apikey_type = "SERVICE_ACCOUNT"
+  parent_id   = "service_account_id"
+}
+
+provider "harness" {
+  alias            = "service_account"
+  platform_api_key = ephemeral.harness_platform_token.service_account.value
+}
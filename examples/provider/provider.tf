@@ -19,3 +19,16 @@ provider "harness" {
   account_id       = "...."
   platform_api_key = "......"
 }
+
+#Configure default scope and tags for Next Gen resources
+provider "harness" {
+  endpoint           = "https://app.harness.io/gateway"
+  account_id         = "...."
+  platform_api_key   = "......"
+  default_org_id     = "default"
+  default_project_id = "my_project"
+
+  default_tags {
+    tags = ["team:platform", "managed-by:terraform"]
+  }
+}
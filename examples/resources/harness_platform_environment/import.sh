@@ -6,3 +6,6 @@ terraform import harness_platform_environment.example <org_id>/<environment_id>
 
 # Import project level environment id
 terraform import harness_platform_environment.example <org_id>/<project_id>/<environment_id>
+
+# Import project level environment from non default branch
+terraform import harness_platform_environment.example <org_id>/<project_id>/<environment_id>@<branch>
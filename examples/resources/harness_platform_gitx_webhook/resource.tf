@@ -1,6 +1,6 @@
 //Create Project level webhook
 resource "harness_platform_gitx_webhook" "test" {
-  identifier    = "webhook Identifier"
+  identifier    = "webhook_identifier"
   name          = "webhook name"
   project_id    = "projectIdentifier"
   org_id        = "orgIdentifier"
@@ -10,7 +10,7 @@ resource "harness_platform_gitx_webhook" "test" {
 
 //Create Org level webhook
 resource "harness_platform_gitx_webhook" "test" {
-  identifier    = "webhook Identifier"
+  identifier    = "webhook_identifier"
   name          = "webhook name"
   org_id        = "orgIdentifier"
   repo_name     = "repo name"
@@ -19,7 +19,7 @@ resource "harness_platform_gitx_webhook" "test" {
 
 //Create Account level webhook
 resource "harness_platform_gitx_webhook" "test" {
-  identifier    = "webhook Identifier"
+  identifier    = "webhook_identifier"
   name          = "webhook name"
   repo_name     = "repo name"
   connector_ref = "connectorRef"
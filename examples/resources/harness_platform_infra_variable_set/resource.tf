@@ -41,11 +41,11 @@ resource "harness_platform_infra_module" "example" {
   }
 
   connector {
-    connector_ref = "harness_platform_connector_aws.test.id"
+    connector_ref = harness_platform_connector_aws.test.id
     type          = "aws"
   }
   connector {
-    connector_ref = "harness_platform_connector_azure.test.id"
+    connector_ref = harness_platform_connector_azure.test.id
     type          = "azure"
   }
 
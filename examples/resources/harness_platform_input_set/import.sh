@@ -1,2 +1,5 @@
 # Import input set 
 terraform import harness_platform_input_set.example <org_id>/<project_id>/<pipeline_id>/<input_set_id>
+
+# Import input set from non default branch
+terraform import harness_platform_input_set.example <org_id>/<project_id>/<pipeline_id>/<input_set_id>@<branch>
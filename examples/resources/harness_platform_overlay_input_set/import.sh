@@ -0,0 +1,5 @@
+# Import overlay input set
+terraform import harness_platform_overlay_input_set.example <org_id>/<project_id>/<pipeline_id>/<overlay_input_set_id>
+
+# Import overlay input set from non default branch
+terraform import harness_platform_overlay_input_set.example <org_id>/<project_id>/<pipeline_id>/<overlay_input_set_id>@<branch>
@@ -0,0 +1,36 @@
+resource "harness_platform_overlay_input_set" "example" {
+  identifier  = "identifier"
+  name        = "name"
+  description = "Production values, then the overrides of the release"
+  tags = [
+    "foo:bar",
+  ]
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+  input_set_references = [
+    "production",
+    "release_overrides",
+  ]
+}
+
+# Remote overlay input set
+resource "harness_platform_overlay_input_set" "remote" {
+  identifier  = "identifier"
+  name        = "name"
+  org_id      = harness_platform_organization.test.id
+  project_id  = harness_platform_project.test.id
+  pipeline_id = harness_platform_pipeline.test.id
+  input_set_references = [
+    harness_platform_input_set.production.id,
+    harness_platform_input_set.release_overrides.id,
+  ]
+  git_details {
+    branch_name    = "main"
+    commit_message = "Commit"
+    file_path      = ".harness/overlay.yaml"
+    connector_ref  = "account.connector_ref"
+    store_type     = "REMOTE"
+    repo_name      = "repo_name"
+  }
+}
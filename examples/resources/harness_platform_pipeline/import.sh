@@ -2,4 +2,4 @@
 terraform import harness_platform_pipeline.example <org_id>/<project_id>/<pipeline_id>
 
 # Import pipeline from non default branch
-terraform import harness_platform_pipeline.example <org_id>/<project_id>/<pipeline_id>/<branch>
+terraform import harness_platform_pipeline.example <org_id>/<project_id>/<pipeline_id>@<branch>
@@ -0,0 +1,34 @@
+# Run a pipeline with input sets
+resource "harness_platform_pipeline_execution" "example" {
+  org_id        = "org_id"
+  project_id    = "project_id"
+  pipeline_id   = "pipeline_id"
+  input_set_ids = ["input_set_id"]
+}
+
+# Run a pipeline with runtime inputs, again each time the version changes
+resource "harness_platform_pipeline_execution" "deploy" {
+  org_id      = "org_id"
+  project_id  = "project_id"
+  pipeline_id = "pipeline_id"
+  runtime_input_yaml = <<-EOT
+    pipeline:
+      identifier: pipeline_id
+      variables:
+        - name: version
+          type: String
+          value: ${var.version}
+  EOT
+
+  triggers = {
+    version = var.version
+  }
+
+  timeouts {
+    create = "1h"
+  }
+}
+
+output "deployed_url" {
+  value = harness_platform_pipeline_execution.deploy.stages[0].outputs["deploy.url"]
+}
@@ -1,5 +1,5 @@
 resource "harness_platform_policyset" "test" {
-  identifier = "harness_platform_policyset.test.identifier"
+  identifier = "policyset_identifier"
   name       = "harness_platform_policyset.test.name"
   action     = "onrun"
   type       = "pipeline"
@@ -12,7 +12,7 @@ resource "harness_platform_policyset" "test" {
 
 ## Policyset with multiple policies
 resource "harness_platform_policyset" "test" {
-  identifier = "harness_platform_policyset.test.identifier"
+  identifier = "policyset_identifier"
   name       = "harness_platform_policyset.test.name"
   action     = "onrun"
   type       = "pipeline"
@@ -35,4 +35,18 @@ resource "harness_platform_secret_text" "gcp_secret_manager_reference" {
       version = "1"
     }
   }
-}
\ No newline at end of file
+}
+
+# The value is never stored in the plan or state with value_wo, which requires Terraform 1.11 or later.
+# Increment value_wo_version to update the secret with a new value.
+resource "harness_platform_secret_text" "write_only" {
+  identifier  = "identifier"
+  name        = "name"
+  description = "example"
+  tags        = ["foo:bar"]
+
+  secret_manager_identifier = "harnessSecretManager"
+  value_type                = "Inline"
+  value_wo                  = var.secret_value
+  value_wo_version          = 1
+}
@@ -6,3 +6,6 @@ terraform import harness_platform_service.example <org_id>/<service_id>
 
 # Import project level service 
 terraform import harness_platform_service.example <org_id>/<project_id>/<service_id>
+
+# Import project level service from non default branch
+terraform import harness_platform_service.example <org_id>/<project_id>/<service_id>@<branch>
@@ -6,3 +6,6 @@ terraform import harness_platform_template.example <ord_id>/<template_id>
 
 # Import project level template
 terraform import harness_platform_template.example <org_id>/<project_id>/<template_id>
+
+# Import project level template from non default branch
+terraform import harness_platform_template.example <org_id>/<project_id>/<template_id>@<branch>
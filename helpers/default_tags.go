@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GetTagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Tags associated with the resource, including the tags inherited from the provider `default_tags` block.",
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// SetDefaultTags merges the provider level default_tags into the tags sent to the API by resources with the
+// tags_all attribute added by SetCommonResourceSchema. The merged tags are exposed in the computed tags_all
+// attribute, while the tags attribute only keeps the tags set on the resource so that default tags never show
+// up as drift.
+func SetDefaultTags(r *schema.Resource) {
+	if _, ok := r.Schema["tags_all"]; !ok {
+		return
+	}
+	if r.CreateContext == nil || r.ReadContext == nil || r.UpdateContext == nil {
+		return
+	}
+
+	create, read, update := r.CreateContext, r.ReadContext, r.UpdateContext
+	r.CreateContext = withDefaultTags(create)
+	r.UpdateContext = withDefaultTags(update)
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		tags, isMap := ExpandResourceTags(d)
+		diags := read(ctx, d, meta)
+		setTagsAll(d, getDefaultTags(meta), tags, isMap)
+		return diags
+	}
+	prependCustomizeDiff(r, defaultTagsCustomizeDiff)
+}
+
+func withDefaultTags(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		defaultTags := getDefaultTags(meta)
+		tags, isMap := ExpandResourceTags(d)
+		d.Set("tags", MergeTags(defaultTags, tags))
+		diags := f(ctx, d, meta)
+		setTagsAll(d, defaultTags, tags, isMap)
+		return diags
+	}
+}
+
+// setTagsAll copies the tags returned by the API into tags_all and removes the default tags that are not set
+// on the resource from tags, or from tags_map when the resource tags are set as a map.
+func setTagsAll(d *schema.ResourceData, defaultTags map[string]string, tags []interface{}, isMap bool) {
+	if d.Id() == "" {
+		return
+	}
+
+	all := d.Get("tags").(*schema.Set).List()
+	resourceTags := ExpandTags(tags)
+
+	result := map[string]string{}
+	for k, v := range ExpandTags(all) {
+		if dv, ok := defaultTags[k]; ok && dv == v {
+			if rv, ok := resourceTags[k]; !ok || rv != v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+
+	d.Set("tags_all", all)
+	FlattenResourceTags(d, result, isMap)
+}
+
+func defaultTagsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") || !d.NewValueKnown("tags_map") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tags, _ := ExpandResourceTags(d)
+	tagsAll := MergeTags(getDefaultTags(meta), tags)
+	old := FlattenTags(ExpandTags(d.Get("tags_all").(*schema.Set).List()))
+	sort.Strings(old)
+	if d.Id() != "" && equalStrings(old, tagsAll) {
+		return nil
+	}
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// MergeTags returns the default tags merged with the given tags, the latter taking precedence when the
+// same key is present in both. The result is sorted.
+func MergeTags(defaultTags map[string]string, tags []interface{}) []string {
+	merged := map[string]string{}
+	for k, v := range defaultTags {
+		merged[k] = v
+	}
+	for k, v := range ExpandTags(tags) {
+		merged[k] = v
+	}
+
+	result := FlattenTags(merged)
+	sort.Strings(result)
+	return result
+}
+
+func getDefaultTags(meta interface{}) map[string]string {
+	session, ok := meta.(*internal.Session)
+	if !ok || session == nil {
+		return nil
+	}
+	return session.DefaultTags
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTags(t *testing.T) {
+	defaultTags := map[string]string{"team": "platform", "managed-by": "terraform"}
+
+	require.Equal(t, []string{"managed-by:terraform", "team:platform"}, MergeTags(defaultTags, nil))
+	require.Equal(t, []string{"env:dev", "managed-by:terraform", "team:core"}, MergeTags(defaultTags, []interface{}{"team:core", "env:dev"}))
+	require.Equal(t, []string{"env:dev"}, MergeTags(nil, []interface{}{"env:dev"}))
+}
+
+func TestSetDefaultTags(t *testing.T) {
+	var sentTags map[string]string
+	r := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			sentTags = ExpandTags(d.Get("tags").(*schema.Set).List())
+			d.SetId("test")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	}
+	SetCommonResourceSchema(r.Schema)
+	SetDefaultTags(r)
+
+	session := &internal.Session{DefaultTags: map[string]string{"team": "platform", "env": "prod"}}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"identifier": "test",
+		"name":       "test",
+		"tags":       []interface{}{"env:dev"},
+	})
+
+	diags := r.CreateContext(context.Background(), d, session)
+	require.False(t, diags.HasError())
+	require.Equal(t, map[string]string{"team": "platform", "env": "dev"}, sentTags)
+	require.ElementsMatch(t, []interface{}{"env:dev"}, d.Get("tags").(*schema.Set).List())
+	require.ElementsMatch(t, []interface{}{"env:dev", "team:platform"}, d.Get("tags_all").(*schema.Set).List())
+}
+
+func TestSetDefaultTags_tagsMap(t *testing.T) {
+	var sentTags map[string]string
+	apiTags := map[string]string{}
+	r := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			sentTags = ExpandTags(d.Get("tags").(*schema.Set).List())
+			apiTags = sentTags
+			d.SetId("test")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.Set("tags", FlattenTags(apiTags))
+			return nil
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	}
+	SetCommonResourceSchema(r.Schema)
+	SetDefaultTags(r)
+
+	session := &internal.Session{DefaultTags: map[string]string{"team": "platform"}}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"identifier": "test",
+		"name":       "test",
+		"tags_map":   map[string]interface{}{"url": "https://harness.io:443", "empty": ""},
+	})
+
+	diags := r.CreateContext(context.Background(), d, session)
+	require.False(t, diags.HasError())
+	require.Equal(t, map[string]string{"team": "platform", "url": "https://harness.io:443", "empty": ""}, sentTags)
+
+	diags = r.ReadContext(context.Background(), d, session)
+	require.False(t, diags.HasError())
+	require.Empty(t, d.Get("tags").(*schema.Set).List())
+	require.Equal(t, map[string]interface{}{"url": "https://harness.io:443", "empty": ""}, d.Get("tags_map"))
+	require.ElementsMatch(t, []interface{}{"empty", "team:platform", "url:https://harness.io:443"}, d.Get("tags_all").(*schema.Set).List())
+}
+
+func TestValidateTagsMap(t *testing.T) {
+	s := GetTagsMapSchema()
+	require.False(t, s.ValidateDiagFunc(map[string]interface{}{"url": "https://harness.io"}, nil).HasError())
+	require.True(t, s.ValidateDiagFunc(map[string]interface{}{"team:core": ""}, nil).HasError())
+}
@@ -2,138 +2,342 @@ package helpers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/harness/harness-go-sdk/harness/chaos"
+	"github.com/harness/harness-go-sdk/harness/code"
+	"github.com/harness/harness-go-sdk/harness/dbops"
+	"github.com/harness/harness-go-sdk/harness/har"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	openapi_client_nextgen "github.com/harness/harness-openapi-go-client/nextgen"
-	"google.golang.org/grpc/codes"
-
-	"github.com/harness/harness-go-sdk/harness/dbops"
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	unauthorizedHint = "Hint:\n" +
+		"1) Please check if token has expired or is wrong.\n" +
+		"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key."
+	forbiddenHint = "Hint:\n" +
+		"1) Please check if the token has required permission for this operation.\n" +
+		"2) Please check if the token has expired or is wrong."
 )
 
+// requestIdHeaders are the response headers identifying a request in the Harness logs.
+var requestIdHeaders = []string{"X-Request-Id", "X-Correlation-Id"}
+
+// HandleApiError converts the error returned by any of the Harness API clients (nextgen, openapi, dbops, code,
+// chaos and har) to diagnostics.
 func HandleApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
 	return handleApiError(err, d, httpResp, false)
 }
 
-func HandleDBOpsApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
-	erro, ok := err.(dbops.GenericSwaggerError)
-	if ok && httpResp != nil {
-		if httpResp.StatusCode == 401 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if token has expired or is wrong.\n" +
-				"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key.")
-		}
-		if httpResp.StatusCode == 403 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if the token has required permission for this operation.\n" +
-				"2) Please check if the token has expired or is wrong.")
-		}
-		if httpResp.StatusCode == 404 {
-			return diag.Errorf("resource with ID %s not found: %v", d.Id(), erro.Error())
+// HandleReadApiError converts the error returned by any of the Harness API clients to diagnostics, and removes
+// the resource from the state when the API reports that it does not exist.
+func HandleReadApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
+	return handleApiError(err, d, httpResp, true)
+}
+
+// AddApiError adds the error returned by any of the Harness API clients to the diagnostics of a resource
+// implemented with terraform-plugin-framework. The errors of the request fields are attached to the matching
+// attributes among the given ones.
+func AddApiError(diags *fwdiag.Diagnostics, err error, httpResp *http.Response, attributes ...string) {
+	hasAttribute := func(name string) bool {
+		return slices.Contains(attributes, name)
+	}
+	for _, d := range parseApiError(err, httpResp).diagnostics(nil, hasAttribute) {
+		if len(d.AttributePath) > 0 {
+			diags.AddAttributeError(path.Root(d.AttributePath[0].(cty.GetAttrStep).Name), d.Summary, d.Detail)
+		} else {
+			diags.AddError(d.Summary, d.Detail)
 		}
 	}
+}
 
-	return diag.Errorf(err.Error())
+// ConfigAttributes returns the names of the top level attributes of a terraform-plugin-framework configuration.
+func ConfigAttributes(config tfsdk.Config) []string {
+	var attributes []string
+	for name := range config.Schema.GetAttributes() {
+		attributes = append(attributes, name)
+	}
+	return attributes
 }
 
-func handleApiError(err error, d *schema.ResourceData, httpResp *http.Response, read bool) diag.Diagnostics {
-	erro, ok := err.(nextgen.GenericSwaggerError)
-	if ok {
-		errMessage := erro.Error()
-		gitopsErr, gitopsErrOk := erro.Model().(nextgen.GatewayruntimeError)
-		if gitopsErrOk {
-			errMessage = gitopsErr.Message
-		}
+func HandleDBOpsApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
+	return handleApiError(err, d, httpResp, false)
+}
 
-		if httpResp != nil && httpResp.StatusCode == 401 {
-			return diag.Errorf("%s", httpResp.Status+"\n"+"Hint:\n"+
-				"1) Please check if token has expired or is wrong.\n"+
-				"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key.")
-		}
-		if gitopsErrOk && httpResp != nil && httpResp.StatusCode == 403 {
-			if len(errMessage) > 0 {
-				return diag.Errorf("%s", httpResp.Status+"\n"+"Hint:\n"+
-					"1) Please check if the token has required permission for this operation.\n"+
-					"2) Please check if the token has expired or is wrong.\n"+
-					"3) "+errMessage)
+func HandleDBOpsReadApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
+	return handleApiError(err, d, httpResp, true)
+}
+
+// swaggerError is implemented by the GenericSwaggerError of every generated Harness API client.
+type swaggerError interface {
+	error
+	Body() []byte
+	Model() interface{}
+}
+
+// apiError holds the details of a failed API call in a client independent form.
+type apiError struct {
+	err         error
+	client      string
+	statusCode  int
+	status      string
+	code        string
+	message     string
+	requestId   string
+	fieldErrors []fieldError
+
+	gitops     bool
+	gitopsCode codes.Code
+}
+
+type fieldError struct {
+	field   string
+	message string
+}
+
+// errorBody contains the fields of the error responses of all the Harness APIs.
+type errorBody struct {
+	Code            json.RawMessage `json:"code"`
+	Message         string          `json:"message"`
+	DetailedMessage string          `json:"detailedMessage"`
+	Description     string          `json:"description"`
+	Error           string          `json:"error"`
+	CorrelationId   string          `json:"correlationId"`
+	CorrelationId_  string          `json:"correlation_id"`
+	Errors          []struct {
+		FieldId string `json:"fieldId"`
+		Error   string `json:"error"`
+	} `json:"errors"`
+	ResponseMessages []struct {
+		Message string `json:"message"`
+	} `json:"responseMessages"`
+}
+
+func parseApiError(err error, httpResp *http.Response) *apiError {
+	e := &apiError{err: err, message: err.Error()}
+
+	if httpResp != nil {
+		e.statusCode = httpResp.StatusCode
+		e.status = httpResp.Status
+		for _, header := range requestIdHeaders {
+			if id := httpResp.Header.Get(header); id != "" {
+				e.requestId = id
+				break
 			}
 		}
-		if httpResp != nil && httpResp.StatusCode == 403 {
-			return diag.Errorf("%s", httpResp.Status+"\n"+"Hint:\n"+
-				"1) Please check if the token has required permission for this operation.\n"+
-				"2) Please check if the token has expired or is wrong.")
-		}
-		if httpResp != nil && httpResp.StatusCode == 404 {
-			// GitOps handling for NotFound
-			if gitopsErrOk && read {
-				if codes.Code(gitopsErr.Code) == codes.NotFound {
-					d.SetId("")
-					d.MarkNewResource()
-					return nil
-				}
-			}
-			return diag.Errorf("resource with ID %s not found: %v", d.Id(), errMessage)
+	}
+
+	swaggerErr, ok := err.(swaggerError)
+	if !ok {
+		return e
+	}
+	e.client = clientFamily(err)
+
+	if gitopsErr, ok := swaggerErr.Model().(nextgen.GatewayruntimeError); ok {
+		e.gitops = true
+		e.gitopsCode = codes.Code(gitopsErr.Code)
+		if gitopsErr.Message != "" {
+			e.message = gitopsErr.Message
 		}
-		if read && !gitopsErrOk {
-			if erro.Model() != nil && (erro.Code() == nextgen.ErrorCodes.ResourceNotFound || erro.Code() == nextgen.ErrorCodes.EntityNotFound) {
-				d.SetId("")
-				d.MarkNewResource()
-				return nil
-			}
+	}
+
+	var body errorBody
+	if jsonErr := json.Unmarshal(swaggerErr.Body(), &body); jsonErr != nil {
+		return e
+	}
+
+	var errCode string
+	if json.Unmarshal(body.Code, &errCode) == nil {
+		e.code = errCode
+	}
+	if message := firstNonEmpty(body.Message, body.Error, body.Description, body.DetailedMessage); message != "" {
+		e.message = message
+	}
+	if e.message == "" && len(body.ResponseMessages) > 0 {
+		e.message = body.ResponseMessages[0].Message
+	}
+	if id := firstNonEmpty(body.CorrelationId, body.CorrelationId_); id != "" {
+		e.requestId = id
+	}
+	for _, fe := range body.Errors {
+		if fe.Error != "" {
+			e.fieldErrors = append(e.fieldErrors, fieldError{field: fe.FieldId, message: fe.Error})
 		}
-		return diag.Errorf(errMessage)
 	}
 
-	err_openapi_client, ok := err.(openapi_client_nextgen.GenericSwaggerError)
-	if ok {
-		if httpResp != nil && httpResp.StatusCode == 401 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if token has expired or is wrong.\n" +
-				"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key.")
+	return e
+}
+
+func clientFamily(err error) string {
+	switch err.(type) {
+	case nextgen.GenericSwaggerError:
+		return "nextgen"
+	case openapi_client_nextgen.GenericSwaggerError:
+		return "openapi"
+	case dbops.GenericSwaggerError:
+		return "dbops"
+	case code.GenericSwaggerError:
+		return "code"
+	case chaos.GenericSwaggerError:
+		return "chaos"
+	case har.GenericSwaggerError:
+		return "har"
+	}
+	return "unknown"
+}
+
+// isNotFound reports whether the API reported that the entity does not exist, which removes it from the state
+// when reading.
+func (e *apiError) isNotFound() bool {
+	if e.gitops {
+		return e.statusCode == http.StatusNotFound && e.gitopsCode == codes.NotFound
+	}
+	switch e.client {
+	case "dbops", "code", "chaos", "har":
+		if e.statusCode == http.StatusNotFound {
+			return true
 		}
-		if httpResp != nil && httpResp.StatusCode == 403 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if the token has required permission for this operation.\n" +
-				"2) Please check if the token has expired or is wrong.")
+	}
+	return e.code == string(nextgen.ErrorCodes.ResourceNotFound) || e.code == string(nextgen.ErrorCodes.EntityNotFound)
+}
+
+func handleApiError(err error, d *schema.ResourceData, httpResp *http.Response, read bool) diag.Diagnostics {
+	e := parseApiError(err, httpResp)
+
+	if read && e.client != "" && e.statusCode != http.StatusUnauthorized && e.statusCode != http.StatusForbidden && e.isNotFound() {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	return e.diagnostics(d, func(name string) bool {
+		return d != nil && hasAttribute(d, name)
+	})
+}
+
+// diagnostics converts the error to diagnostics, attaching the errors of the request fields to the attributes for
+// which hasAttribute returns true.
+func (e *apiError) diagnostics(d *schema.ResourceData, hasAttribute func(name string) bool) diag.Diagnostics {
+	main := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  e.message,
+	}
+
+	var details []string
+	switch {
+	case e.statusCode == http.StatusUnauthorized:
+		main.Summary = e.status
+		details = append(details, unauthorizedHint)
+	case e.statusCode == http.StatusForbidden:
+		main.Summary = e.status
+		hint := forbiddenHint
+		if e.message != "" && e.message != e.err.Error() {
+			hint += "\n3) " + e.message
 		}
-		if httpResp != nil && httpResp.StatusCode == 404 {
-			return diag.Errorf("resource with ID %s not found: %v", d.Id(), erro.Error())
+		details = append(details, hint)
+	case e.statusCode == http.StatusNotFound && d != nil:
+		main.Summary = fmt.Sprintf("resource with ID %s not found", d.Id())
+		details = append(details, e.message)
+	}
+
+	if metadata := e.metadata(); metadata != "" {
+		details = append(details, metadata)
+	}
+	main.Detail = strings.Join(details, "\n\n")
+
+	diags := diag.Diagnostics{main}
+	for _, fe := range e.fieldErrors {
+		fieldDiag := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fe.message,
+			Detail:   fmt.Sprintf("The Harness API rejected the value of %s.", fe.field),
 		}
-		var jsonMap map[string]interface{}
-		err := json.Unmarshal(err_openapi_client.Body(), &jsonMap)
-		if err == nil {
-			return diag.Errorf(jsonMap["message"].(string))
+		if attribute := attributeForField(hasAttribute, fe.field); attribute != "" {
+			fieldDiag.AttributePath = cty.GetAttrPath(attribute)
 		}
-		return diag.Errorf(err_openapi_client.Error())
+		diags = append(diags, fieldDiag)
 	}
 
-	return diag.Errorf(err.Error())
+	return diags
 }
 
-func HandleReadApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
-	return handleApiError(err, d, httpResp, true)
+func (e *apiError) metadata() string {
+	var lines []string
+	if e.status != "" {
+		lines = append(lines, "HTTP status: "+e.status)
+	}
+	if e.code != "" {
+		lines = append(lines, "Error code: "+e.code)
+	}
+	if e.requestId != "" {
+		lines = append(lines, "Request ID: "+e.requestId)
+	}
+	return strings.Join(lines, "\n")
 }
 
-func HandleDBOpsReadApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
-	_, ok := err.(dbops.GenericSwaggerError)
-	if ok && httpResp != nil {
-		if httpResp.StatusCode == 401 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if token has expired or is wrong.\n" +
-				"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key.")
+var camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// fieldAttributes maps the fields of the Harness API that are named differently in the schema.
+var fieldAttributes = map[string]string{
+	"orgIdentifier":     "org_id",
+	"projectIdentifier": "project_id",
+	"accountIdentifier": "account_id",
+	"pipeline_yaml":     "yaml",
+}
+
+// attributeForField returns the top level attribute of the resource corresponding to the field named in a validation
+// error, or an empty string when there is none.
+func attributeForField(hasAttribute func(name string) bool, field string) string {
+	if field == "" {
+		return ""
+	}
+
+	parts := strings.FieldsFunc(field, func(r rune) bool { return r == '.' || r == '[' || r == ']' })
+	if len(parts) == 0 {
+		return ""
+	}
+	name := parts[len(parts)-1]
+
+	candidates := []string{}
+	if attribute, ok := fieldAttributes[name]; ok {
+		candidates = append(candidates, attribute)
+	}
+	candidates = append(candidates, strings.ToLower(camelCaseBoundary.ReplaceAllString(name, "${1}_${2}")))
+
+	for _, candidate := range candidates {
+		if hasAttribute(candidate) {
+			return candidate
 		}
-		if httpResp.StatusCode == 403 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if the token has required permission for this operation.\n" +
-				"2) Please check if the token has expired or is wrong.")
+	}
+	return ""
+}
+
+func hasAttribute(d *schema.ResourceData, name string) bool {
+	for _, v := range []cty.Value{d.GetRawConfig(), d.GetRawState(), d.GetRawPlan()} {
+		if t := v.Type(); t.IsObjectType() && t.HasAttribute(name) {
+			return true
 		}
-		if httpResp.StatusCode == 404 {
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
 		}
 	}
-	return diag.Errorf(err.Error())
+	return ""
 }
@@ -0,0 +1,167 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/har"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-retryablehttp"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorServer(t *testing.T, status int, body string) (*httptest.Server, *retryablehttp.Client) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "header-request-id")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = 0
+	return server, httpClient
+}
+
+func getOrganizationError(t *testing.T, status int, body string) (error, *http.Response) {
+	server, httpClient := newErrorServer(t, status, body)
+	c := nextgen.NewAPIClient(&nextgen.Configuration{BasePath: server.URL, HTTPClient: httpClient})
+	_, httpResp, err := c.OrganizationApi.GetOrganization(context.Background(), "org", "account")
+	require.Error(t, err)
+	return err, httpResp
+}
+
+func getRegistryError(t *testing.T, status int, body string) (error, *http.Response) {
+	server, httpClient := newErrorServer(t, status, body)
+	c := har.NewAPIClient(&har.Configuration{BasePath: server.URL, HTTPClient: httpClient})
+	_, httpResp, err := c.RegistriesApi.GetRegistry(context.Background(), "account/org/registry/+")
+	require.Error(t, err)
+	return err, httpResp
+}
+
+func createTestResourceData(t *testing.T) *schema.ResourceData {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"identifier": {Type: schema.TypeString, Optional: true},
+			"org_id":     {Type: schema.TypeString, Optional: true},
+			"name":       {Type: schema.TypeString, Optional: true},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"identifier": "org"})
+	d.SetId("org")
+	return d
+}
+
+func TestHandleApiError_validationErrors(t *testing.T) {
+	err, httpResp := getOrganizationError(t, http.StatusBadRequest, `{
+		"status": "ERROR",
+		"code": "INVALID_REQUEST",
+		"message": "Invalid request",
+		"correlationId": "correlation-id",
+		"errors": [
+			{"fieldId": "organization.name", "error": "must not be blank"},
+			{"fieldId": "orgIdentifier", "error": "must match pattern"},
+			{"fieldId": "unknownField", "error": "is invalid"}
+		]
+	}`)
+
+	diags := HandleApiError(err, createTestResourceData(t), httpResp)
+	require.Len(t, diags, 4)
+
+	assert.Equal(t, "Invalid request", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "HTTP status: 400 Bad Request")
+	assert.Contains(t, diags[0].Detail, "Error code: INVALID_REQUEST")
+	assert.Contains(t, diags[0].Detail, "Request ID: correlation-id")
+
+	assert.Equal(t, "must not be blank", diags[1].Summary)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[1].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("org_id"), diags[2].AttributePath)
+	assert.Nil(t, diags[3].AttributePath)
+}
+
+func TestAddApiError(t *testing.T) {
+	err, httpResp := getOrganizationError(t, http.StatusBadRequest, `{
+		"status": "ERROR",
+		"code": "INVALID_REQUEST",
+		"message": "Invalid request",
+		"errors": [
+			{"fieldId": "orgIdentifier", "error": "must match pattern"},
+			{"fieldId": "organization.name", "error": "must not be blank"}
+		]
+	}`)
+
+	var diags fwdiag.Diagnostics
+	AddApiError(&diags, err, httpResp, "identifier", "org_id")
+	require.Len(t, diags, 3)
+
+	assert.Equal(t, "Invalid request", diags[0].Summary())
+	_, ok := diags[0].(fwdiag.DiagnosticWithPath)
+	assert.False(t, ok)
+	assert.Equal(t, "must match pattern", diags[1].Summary())
+	assert.Equal(t, path.Root("org_id"), diags[1].(fwdiag.DiagnosticWithPath).Path())
+	assert.Equal(t, "must not be blank", diags[2].Summary())
+	_, ok = diags[2].(fwdiag.DiagnosticWithPath)
+	assert.False(t, ok)
+}
+
+func TestHandleApiError_unauthorized(t *testing.T) {
+	err, httpResp := getOrganizationError(t, http.StatusUnauthorized, `{"status":"ERROR","code":"INVALID_TOKEN","message":"Token is not valid."}`)
+
+	diags := HandleApiError(err, createTestResourceData(t), httpResp)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "401 Unauthorized", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "platform_api_key")
+	assert.Contains(t, diags[0].Detail, "Request ID: header-request-id")
+}
+
+func TestHandleReadApiError_notFound(t *testing.T) {
+	err, httpResp := getOrganizationError(t, http.StatusBadRequest, `{"status":"ERROR","code":"RESOURCE_NOT_FOUND_EXCEPTION","message":"Organization not found"}`)
+
+	d := createTestResourceData(t)
+	diags := HandleReadApiError(err, d, httpResp)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, d.Id())
+
+	d = createTestResourceData(t)
+	diags = HandleApiError(err, d, httpResp)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "org", d.Id())
+}
+
+func TestHandleReadApiError_notFoundStatus(t *testing.T) {
+	err, httpResp := getRegistryError(t, http.StatusNotFound, `{"code":"404","message":"registry doesn't exist"}`)
+
+	d := createTestResourceData(t)
+	diags := HandleReadApiError(err, d, httpResp)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, d.Id())
+
+	d = createTestResourceData(t)
+	diags = HandleApiError(err, d, httpResp)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "resource with ID org not found", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "registry doesn't exist")
+
+	// The nextgen APIs report missing entities with an error code, a 404 alone is not enough to drop the resource.
+	err, httpResp = getOrganizationError(t, http.StatusNotFound, `{"status":"ERROR","code":"INVALID_REQUEST","message":"Not found"}`)
+	d = createTestResourceData(t)
+	diags = HandleReadApiError(err, d, httpResp)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "org", d.Id())
+}
+
+func TestHandleApiError_transportError(t *testing.T) {
+	diags := HandleApiError(errors.New("connection refused"), createTestResourceData(t), nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "connection refused", diags[0].Summary)
+	assert.Empty(t, diags[0].Detail)
+}
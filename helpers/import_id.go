@@ -0,0 +1,205 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ImportIdFormat describes the import id of a resource. Import ids are made of the scope of the resource followed
+// by the fields identifying it within that scope, optionally followed by the git branch to import it from:
+//
+//	[<org_id>/[<project_id>/]]<field>/.../<field>[@<branch>]
+type ImportIdFormat struct {
+	// Fields are the names of the parts following the scope, usually ending with the identifier of the resource.
+	Fields []string
+	// Account, Org and Project are the scopes the resource can be created at.
+	Account bool
+	Org     bool
+	Project bool
+	// Branch allows the id to end with @<branch> for resources stored in git.
+	Branch bool
+}
+
+// ImportId is an import id parsed with ParseImportId.
+type ImportId struct {
+	OrgId     string
+	ProjectId string
+	// Parts are the values of the fields of the format, in the same order.
+	Parts  []string
+	Branch string
+}
+
+// String formats the import id in the grammar accepted by ParseImportId.
+func (id *ImportId) String() string {
+	return FormatImportId(id.OrgId, id.ProjectId, id.Branch, id.Parts...)
+}
+
+// FormatImportId builds the import id of a resource from its scope, the fields identifying it and the git branch
+// to import it from. Empty org, project and branch values are omitted.
+func FormatImportId(orgId string, projectId string, branch string, parts ...string) string {
+	var segments []string
+	if orgId != "" {
+		segments = append(segments, orgId)
+		if projectId != "" {
+			segments = append(segments, projectId)
+		}
+	}
+	id := strings.Join(append(segments, parts...), "/")
+	if branch != "" {
+		id += "@" + branch
+	}
+	return id
+}
+
+// ParseImportId parses an import id in the given format. The scope of the resource is determined by the number of
+// parts of the id. Ids without a scope fall back to the provider level default_org_id and default_project_id for
+// resources that can't be created at the account level.
+func ParseImportId(id string, format ImportIdFormat, meta interface{}) (*ImportId, error) {
+	result := &ImportId{}
+
+	path := id
+	if format.Branch {
+		if i := strings.Index(id, "@"); i >= 0 {
+			path, result.Branch = id[:i], id[i+1:]
+			if result.Branch == "" {
+				return nil, fmt.Errorf("invalid import id %q: the branch after @ must not be empty", id)
+			}
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	fieldCount := len(format.Fields)
+	defaultOrgId, defaultProjectId := getScopeDefaults(meta)
+
+	var names []string
+	switch {
+	case format.Project && len(parts) == fieldCount+2:
+		result.OrgId, result.ProjectId = parts[0], parts[1]
+		names = []string{"org_id", "project_id"}
+	case format.Org && len(parts) == fieldCount+1:
+		result.OrgId = parts[0]
+		names = []string{"org_id"}
+	case format.Account && len(parts) == fieldCount:
+	case format.Project && len(parts) == fieldCount && defaultOrgId != "" && defaultProjectId != "":
+		result.OrgId, result.ProjectId = defaultOrgId, defaultProjectId
+	case format.Org && len(parts) == fieldCount && defaultOrgId != "":
+		result.OrgId = defaultOrgId
+	default:
+		return nil, fmt.Errorf("invalid import id %q: expected %s", id, format.expected())
+	}
+
+	names = append(names, format.Fields...)
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid import id %q: %s must not be empty", id, names[i])
+		}
+	}
+	result.Parts = parts[len(parts)-fieldCount:]
+
+	return result, nil
+}
+
+// parseTrailingScopeImportId parses the import ids of the resources whose scope follows their identifier, in the
+// format <field>[/<org_id>[/<project_id>]].
+func parseTrailingScopeImportId(id string, field string) (*ImportId, error) {
+	// Empty trailing scopes are ignored so that <field>// is accepted for account level resources.
+	parts := strings.Split(strings.TrimRight(id, "/"), "/")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid import id %q: expected <%s> or <%s>/<org_id> or <%s>/<org_id>/<project_id>", id, field, field, field)
+	}
+	names := []string{field, "org_id", "project_id"}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid import id %q: %s must not be empty", id, names[i])
+		}
+	}
+
+	result := &ImportId{Parts: parts[:1]}
+	if len(parts) > 1 {
+		result.OrgId = parts[1]
+	}
+	if len(parts) > 2 {
+		result.ProjectId = parts[2]
+	}
+	return result, nil
+}
+
+// expected describes the import ids accepted by the format.
+func (format ImportIdFormat) expected() string {
+	fields := make([]string, len(format.Fields))
+	for i, field := range format.Fields {
+		fields[i] = "<" + field + ">"
+	}
+
+	var ids []string
+	add := func(scope ...string) {
+		id := strings.Join(append(scope, fields...), "/")
+		if format.Branch {
+			id += "[@<branch>]"
+		}
+		ids = append(ids, id)
+	}
+	if format.Account {
+		add()
+	}
+	if format.Org {
+		add("<org_id>")
+	}
+	if format.Project {
+		add("<org_id>", "<project_id>")
+	}
+
+	return strings.Join(ids, " or ")
+}
+
+// setScope sets the org_id and project_id of an imported resource.
+func (id *ImportId) setScope(d *schema.ResourceData) {
+	if id.OrgId != "" {
+		d.Set("org_id", id.OrgId)
+	}
+	if id.ProjectId != "" {
+		d.Set("project_id", id.ProjectId)
+	}
+}
+
+// setBranch sets the branch to read an imported resource from in its git_details.
+func (id *ImportId) setBranch(d *schema.ResourceData) error {
+	if id.Branch == "" {
+		return nil
+	}
+	// The git_details of pipelines, input sets and templates name the branch branch_name while the ones of
+	// services and environments name it branch.
+	for _, key := range []string{"branch_name", "branch"} {
+		err := d.Set("git_details", []interface{}{map[string]interface{}{key: id.Branch}})
+		if err == nil && d.Get("git_details.0."+key) == id.Branch {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid import id %q: the resource can't be imported from a git branch", id.String())
+}
+
+// importResource returns an importer parsing the id in the given format and setting the scope, the fields and the
+// branch of the resource. The id of the resource is set to the value of the last field.
+func importResource(format ImportIdFormat) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			id, err := ParseImportId(d.Id(), format, meta)
+			if err != nil {
+				return nil, err
+			}
+
+			id.setScope(d)
+			for i, field := range format.Fields {
+				d.Set(field, id.Parts[i])
+			}
+			if err := id.setBranch(d); err != nil {
+				return nil, err
+			}
+			d.SetId(id.Parts[len(id.Parts)-1])
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
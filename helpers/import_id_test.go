@@ -0,0 +1,138 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var multiLevelFormat = ImportIdFormat{Fields: []string{"identifier"}, Account: true, Org: true, Project: true, Branch: true}
+
+func TestParseImportId_Scopes(t *testing.T) {
+	id, err := ParseImportId("my_id", multiLevelFormat, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportId{Parts: []string{"my_id"}}, id)
+
+	id, err = ParseImportId("org/my_id", multiLevelFormat, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportId{OrgId: "org", Parts: []string{"my_id"}}, id)
+
+	id, err = ParseImportId("org/project/my_id@feature/branch", multiLevelFormat, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportId{OrgId: "org", ProjectId: "project", Parts: []string{"my_id"}, Branch: "feature/branch"}, id)
+	assert.Equal(t, "org/project/my_id@feature/branch", id.String())
+}
+
+func TestParseImportId_Invalid(t *testing.T) {
+	format := ImportIdFormat{Fields: []string{"pipeline_id", "identifier"}, Project: true}
+
+	_, err := ParseImportId("org/project/identifier", format, nil)
+	require.EqualError(t, err, `invalid import id "org/project/identifier": expected <org_id>/<project_id>/<pipeline_id>/<identifier>`)
+
+	_, err = ParseImportId("org//pipeline/identifier", format, nil)
+	require.EqualError(t, err, `invalid import id "org//pipeline/identifier": project_id must not be empty`)
+
+	_, err = ParseImportId("org/my_id@", multiLevelFormat, nil)
+	require.EqualError(t, err, `invalid import id "org/my_id@": the branch after @ must not be empty`)
+
+	_, err = ParseImportId("a/b/c/d", multiLevelFormat, nil)
+	require.EqualError(t, err, `invalid import id "a/b/c/d": expected <identifier>[@<branch>] or <org_id>/<identifier>[@<branch>] or <org_id>/<project_id>/<identifier>[@<branch>]`)
+
+	// The provider level default organization is required to import organization level resources without a scope.
+	_, err = ParseImportId("my_id", ImportIdFormat{Fields: []string{"identifier"}, Org: true}, nil)
+	require.EqualError(t, err, `invalid import id "my_id": expected <org_id>/<identifier>`)
+}
+
+func TestParseImportId_DefaultScope(t *testing.T) {
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+
+	id, err := ParseImportId("pipeline/my_id", ImportIdFormat{Fields: []string{"pipeline_id", "identifier"}, Project: true}, session)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportId{OrgId: "default_org", ProjectId: "default_project", Parts: []string{"pipeline", "my_id"}}, id)
+
+	id, err = ParseImportId("my_id", ImportIdFormat{Fields: []string{"identifier"}, Org: true}, session)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportId{OrgId: "default_org", Parts: []string{"my_id"}}, id)
+
+	// Multi level resources without a scope are imported at the account level.
+	id, err = ParseImportId("my_id", multiLevelFormat, session)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportId{Parts: []string{"my_id"}}, id)
+}
+
+func importState(t *testing.T, importer *schema.ResourceImporter, s map[string]*schema.Schema, id string) (*schema.ResourceData, error) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	d.SetId(id)
+	result, err := importer.State(d, nil)
+	if err != nil {
+		return nil, err
+	}
+	require.Len(t, result, 1)
+	return result[0], nil
+}
+
+func gitResourceSchema(branchKey string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"identifier": {Type: schema.TypeString, Optional: true},
+		"org_id":     {Type: schema.TypeString, Optional: true},
+		"project_id": {Type: schema.TypeString, Optional: true},
+		"git_details": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					branchKey: {Type: schema.TypeString, Optional: true},
+				},
+			},
+		},
+	}
+}
+
+func TestProjectResourceImporter(t *testing.T) {
+	s := gitResourceSchema("branch_name")
+
+	for _, id := range []string{"org/project/my_id@main", "org/project/my_id/main"} {
+		d, err := importState(t, ProjectResourceImporter, s, id)
+		require.NoError(t, err)
+		assert.Equal(t, "my_id", d.Id())
+		assert.Equal(t, "org", d.Get("org_id"))
+		assert.Equal(t, "project", d.Get("project_id"))
+		assert.Equal(t, "main", d.Get("git_details.0.branch_name"))
+	}
+
+	_, err := importState(t, ProjectResourceImporter, s, "my_id")
+	require.Error(t, err)
+}
+
+func TestMultiLevelGitResourceImporter(t *testing.T) {
+	d, err := importState(t, MultiLevelGitResourceImporter, gitResourceSchema("branch"), "org/my_id@main")
+	require.NoError(t, err)
+	assert.Equal(t, "my_id", d.Id())
+	assert.Equal(t, "org", d.Get("org_id"))
+	assert.Equal(t, "main", d.Get("git_details.0.branch"))
+
+	s := gitResourceSchema("branch")
+	delete(s, "git_details")
+	_, err = importState(t, MultiLevelGitResourceImporter, s, "org/my_id@main")
+	require.EqualError(t, err, `invalid import id "org/my_id@main": the resource can't be imported from a git branch`)
+}
+
+func TestUserResourceImporter(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"email":      {Type: schema.TypeString, Optional: true},
+		"org_id":     {Type: schema.TypeString, Optional: true},
+		"project_id": {Type: schema.TypeString, Optional: true},
+	}
+
+	d, err := importState(t, UserResourceImporter, s, "user@harness.io/org")
+	require.NoError(t, err)
+	assert.Equal(t, "user@harness.io", d.Get("email"))
+	assert.Equal(t, "org", d.Get("org_id"))
+
+	_, err = importState(t, UserResourceImporter, s, "user@harness.io/org/project/extra")
+	require.Error(t, err)
+}
@@ -0,0 +1,335 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][0-9a-zA-Z_$]{0,127}$`)
+	referenceRegexp  = regexp.MustCompile(`^((account|org)\.)?[a-zA-Z_][0-9a-zA-Z_$]*$`)
+)
+
+// reservedIdentifiers are the words Harness doesn't accept as identifiers because they are keywords of its
+// expression language.
+var reservedIdentifiers = map[string]bool{
+	"or": true, "and": true, "eq": true, "ne": true, "lt": true, "gt": true, "le": true, "ge": true, "div": true,
+	"mod": true, "not": true, "null": true, "true": true, "false": true, "new": true, "var": true, "return": true,
+	"shellScriptProvisioner": true, "class": true,
+}
+
+// pathReferences are the *_ref attributes that reference an entity by its path instead of the
+// [account.|org.]<identifier> format.
+var pathReferences = map[string]bool{
+	"parent_ref": true,
+	"space_ref":  true,
+}
+
+// ValidateIdentifier validates the identifier of a Harness entity: it must start with a letter or an underscore,
+// contain only letters, digits, underscores and dollar signs, be at most 128 characters long and not be a keyword
+// of the Harness expression language.
+func ValidateIdentifier(i interface{}, p cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{invalidValueDiagnostic(p, "Expected the identifier to be a string.")}
+	}
+	if !identifierRegexp.MatchString(v) {
+		return diag.Diagnostics{invalidValueDiagnostic(p, fmt.Sprintf("%q is not a valid identifier: it must start with a letter or an underscore, contain only letters, digits, underscores and dollar signs, and be at most 128 characters long.", v))}
+	}
+	if reservedIdentifiers[v] {
+		return diag.Diagnostics{invalidValueDiagnostic(p, fmt.Sprintf("%q is a reserved word and can't be used as an identifier.", v))}
+	}
+	return nil
+}
+
+// ValidateReference validates a reference to a Harness entity, like a connector or a secret, in the
+// [account.|org.]<identifier> format. Empty references and expressions like <+input> are not validated.
+func ValidateReference(i interface{}, p cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{invalidValueDiagnostic(p, "Expected the reference to be a string.")}
+	}
+	if v == "" || strings.HasPrefix(v, "<+") {
+		return nil
+	}
+	if !referenceRegexp.MatchString(v) {
+		return diag.Diagnostics{invalidValueDiagnostic(p, fmt.Sprintf("%q is not a valid reference: expected <identifier> for an entity in the same scope as the resource, org.<identifier> for an entity of the organization or account.<identifier> for an entity of the account.", v))}
+	}
+	return nil
+}
+
+func invalidValueDiagnostic(p cty.Path, detail string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       "Invalid value",
+		Detail:        detail,
+		AttributePath: p,
+	}
+}
+
+// isReferenceAttribute returns true for the attributes referencing connectors, secrets and the other entities
+// in the [account.|org.]<identifier> format: connector_ref, secret_manager_identifier and the other *_ref
+// attributes.
+func isReferenceAttribute(name string, s *schema.Schema) bool {
+	if s.Type != schema.TypeString {
+		return false
+	}
+	if !s.Optional && !s.Required {
+		return false
+	}
+	if name == "secret_manager_identifier" {
+		return true
+	}
+	return strings.HasSuffix(name, "_ref") && !pathReferences[name]
+}
+
+// SetReferenceValidation validates the references to connectors, secrets and other entities of a resource at
+// plan time. The format of the references is validated with ValidateReference, and resources with an org_id
+// reject the org. references when they are created at the account level. When verify_references is enabled on
+// the provider, the referenced connectors and secrets must also exist.
+func SetReferenceValidation(r *schema.Resource) {
+	if !setReferenceValidateFuncs(r.Schema) {
+		return
+	}
+
+	appendCustomizeDiff(r, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		orgId, projectId, ok := getReferenceScope(d, r.Schema)
+		if !ok {
+			return nil
+		}
+
+		values := map[string]interface{}{}
+		for k := range r.Schema {
+			values[k] = d.Get(k)
+		}
+		refs := getReferences(r.Schema, values, "")
+
+		var errs []string
+		for path, ref := range refs {
+			if _, ok := r.Schema["org_id"]; ok && orgId == "" && strings.HasPrefix(ref, "org.") {
+				errs = append(errs, fmt.Sprintf("%s: %q references an organization level entity, which can't be used by an account level resource", path, ref))
+			}
+		}
+		if len(errs) == 0 {
+			if session, ok := meta.(*internal.Session); ok && session != nil && session.VerifyReferences {
+				errs = verifyReferences(ctx, session, getChangedReferences(d, r.Schema, refs), orgId, projectId)
+			}
+		}
+
+		if len(errs) > 0 {
+			sort.Strings(errs)
+			return fmt.Errorf("%s", strings.Join(errs, "\n"))
+		}
+		return nil
+	})
+}
+
+// getChangedReferences returns the references to verify: all of them when the resource is created or moved to
+// another scope, only the changed ones otherwise so that unchanged references are not read on every plan.
+func getChangedReferences(d *schema.ResourceDiff, s map[string]*schema.Schema, refs map[string]string) map[string]string {
+	if d.Id() == "" {
+		return refs
+	}
+	for _, key := range []string{"org_id", "project_id"} {
+		if _, ok := s[key]; ok && d.HasChange(key) {
+			return refs
+		}
+	}
+
+	result := map[string]string{}
+	for path, ref := range refs {
+		if d.HasChange(path) {
+			result[path] = ref
+		}
+	}
+	return result
+}
+
+// getReferenceScope returns the org and project the references without a scope prefix resolve to, which are the
+// ones of the resource. It returns false when they are not known yet.
+func getReferenceScope(d *schema.ResourceDiff, s map[string]*schema.Schema) (string, string, bool) {
+	var scope [2]string
+	for i, key := range []string{"org_id", "project_id"} {
+		if _, ok := s[key]; !ok {
+			continue
+		}
+		// An unknown scope that is not set in the configuration is left empty by the scope defaults, which run first.
+		if !d.NewValueKnown(key) && isScopeAttributeSet(d, key) {
+			return "", "", false
+		}
+		scope[i] = d.Get(key).(string)
+	}
+	return scope[0], scope[1], true
+}
+
+// setReferenceValidateFuncs sets ValidateReference on the reference attributes of the schema and of its nested
+// blocks that don't have a validation already. It returns true if the schema has reference attributes.
+func setReferenceValidateFuncs(m map[string]*schema.Schema) bool {
+	found := false
+	for name, s := range m {
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			found = setReferenceValidateFuncs(r.Schema) || found
+			continue
+		}
+		if !isReferenceAttribute(name, s) {
+			continue
+		}
+		found = true
+		if s.ValidateFunc == nil && s.ValidateDiagFunc == nil {
+			s.ValidateDiagFunc = ValidateReference
+		}
+	}
+	return found
+}
+
+// getReferences returns the values of the reference attributes of the schema and of its nested blocks, keyed by
+// their path.
+func getReferences(m map[string]*schema.Schema, values map[string]interface{}, prefix string) map[string]string {
+	result := map[string]string{}
+	for name, s := range m {
+		path := prefix + name
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			var elements []interface{}
+			switch v := values[name].(type) {
+			case []interface{}:
+				elements = v
+			case *schema.Set:
+				elements = v.List()
+			}
+			for i, element := range elements {
+				if element, ok := element.(map[string]interface{}); ok {
+					for k, v := range getReferences(r.Schema, element, path+"."+strconv.Itoa(i)+".") {
+						result[k] = v
+					}
+				}
+			}
+			continue
+		}
+		if !isReferenceAttribute(name, s) {
+			continue
+		}
+		if v, ok := values[name].(string); ok && v != "" {
+			result[path] = v
+		}
+	}
+	return result
+}
+
+// unknownValue is the value the SDK returns for the nested attributes that are not known until apply.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// unverifiedReferences are the *_ref attributes that reference entities other than connectors and secrets.
+var unverifiedReferences = map[string]bool{
+	"template_ref":          true,
+	"service_ref":           true,
+	"environment_ref":       true,
+	"notification_rule_ref": true,
+}
+
+// isConnectorReference returns true for the reference attributes referencing connectors, the other ones
+// referencing secrets.
+func isConnectorReference(name string) bool {
+	return strings.HasSuffix(name, "connector_ref") || strings.HasPrefix(name, "secret_manager_")
+}
+
+// resolveReference returns the scope and the identifier of the entity referenced from a resource in the given
+// scope.
+func resolveReference(ref string, orgId string, projectId string) (string, string, string) {
+	switch {
+	case strings.HasPrefix(ref, "account."):
+		return "", "", strings.TrimPrefix(ref, "account.")
+	case strings.HasPrefix(ref, "org."):
+		return orgId, "", strings.TrimPrefix(ref, "org.")
+	}
+	return orgId, projectId, ref
+}
+
+// verifyReferences checks that the referenced connectors and secrets exist and returns an error message for each
+// missing one. Other API errors are ignored so that the plan doesn't fail when the entity can't be read. The
+// lookups are cached on the session, so that an entity referenced by several resources is only read once.
+func verifyReferences(ctx context.Context, session *internal.Session, refs map[string]string, orgId string, projectId string) []string {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var errs []string
+	for path, ref := range refs {
+		name := path[strings.LastIndex(path, ".")+1:]
+		if ref == unknownValue || strings.HasPrefix(ref, "<+") || unverifiedReferences[name] {
+			continue
+		}
+
+		refOrgId, refProjectId, id := resolveReference(ref, orgId, projectId)
+		kind := "secret"
+		if isConnectorReference(name) {
+			kind = "connector"
+		}
+
+		key := strings.Join([]string{kind, refOrgId, refProjectId, id}, "/")
+		var missing bool
+		if v, ok := session.ReferenceLookups.Load(key); ok {
+			missing = v.(bool)
+		} else {
+			var read bool
+			missing, read = lookupReference(ctx, c, kind, refOrgId, refProjectId, id)
+			if read {
+				session.ReferenceLookups.Store(key, missing)
+			}
+		}
+
+		if missing {
+			errs = append(errs, fmt.Sprintf("%s: the %s %q does not exist in %s", path, kind, ref, describeScope(refOrgId, refProjectId)))
+		}
+	}
+	return errs
+}
+
+// lookupReference returns whether the referenced connector or secret is missing. The second value is false when
+// the entity couldn't be read, in which case it is not reported as missing.
+func lookupReference(ctx context.Context, c *nextgen.APIClient, kind string, orgId string, projectId string, id string) (bool, bool) {
+	var exists bool
+	var httpResp *http.Response
+	var err error
+	if kind == "connector" {
+		var resp nextgen.ResponseDtoConnectorResponse
+		resp, httpResp, err = c.ConnectorsApi.GetConnector(ctx, c.AccountId, id, &nextgen.ConnectorsApiGetConnectorOpts{
+			OrgIdentifier:     OptionalString(orgId),
+			ProjectIdentifier: OptionalString(projectId),
+		})
+		exists = resp.Data != nil && resp.Data.Connector != nil
+	} else {
+		var resp nextgen.ResponseDtoSecretResponse
+		resp, httpResp, err = c.SecretsApi.GetSecretV2(ctx, id, c.AccountId, &nextgen.SecretsApiGetSecretV2Opts{
+			OrgIdentifier:     OptionalString(orgId),
+			ProjectIdentifier: OptionalString(projectId),
+		})
+		exists = resp.Data != nil && resp.Data.Secret != nil
+	}
+
+	switch {
+	case err == nil:
+		return !exists, true
+	case parseApiError(err, httpResp).isNotFound():
+		return true, true
+	}
+	return false, false
+}
+
+func describeScope(orgId string, projectId string) string {
+	switch {
+	case projectId != "":
+		return fmt.Sprintf("the project %s/%s", orgId, projectId)
+	case orgId != "":
+		return fmt.Sprintf("the organization %s", orgId)
+	}
+	return "the account"
+}
@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIdentifier(t *testing.T) {
+	for _, id := range []string{"my_id", "_account_admin", "Id1", "a$b", strings.Repeat("a", 128)} {
+		require.False(t, ValidateIdentifier(id, nil).HasError(), id)
+	}
+	for _, id := range []string{"", "my-id", "1id", "my id", "org.id", "true", "class", strings.Repeat("a", 129)} {
+		require.True(t, ValidateIdentifier(id, nil).HasError(), id)
+	}
+}
+
+func TestValidateReference(t *testing.T) {
+	for _, ref := range []string{"", "secret", "org.secret", "account.harnessSecretManager", "<+input>"} {
+		require.False(t, ValidateReference(ref, nil).HasError(), ref)
+	}
+	for _, ref := range []string{"project.secret", "account.", "org.my-secret", "Account.secret", "org.project.secret"} {
+		require.True(t, ValidateReference(ref, nil).HasError(), ref)
+	}
+}
+
+func TestSetReferenceValidation(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connector_ref": {Type: schema.TypeString, Optional: true},
+			"space_ref":     {Type: schema.TypeString, Optional: true},
+			"credentials": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"password_ref": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	SetMultiLevelResourceSchema(r.Schema)
+	SetReferenceValidation(r)
+	require.NoError(t, r.InternalValidate(nil, true))
+
+	require.NotNil(t, r.Schema["connector_ref"].ValidateDiagFunc)
+	require.NotNil(t, r.Schema["credentials"].Elem.(*schema.Resource).Schema["password_ref"].ValidateDiagFunc)
+	require.Nil(t, r.Schema["space_ref"].ValidateDiagFunc)
+
+	diff := func(raw map[string]interface{}) error {
+		raw["identifier"] = "test"
+		raw["name"] = "test"
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+		return err
+	}
+
+	require.NoError(t, diff(map[string]interface{}{"org_id": "org", "connector_ref": "org.connector"}))
+	require.NoError(t, diff(map[string]interface{}{"connector_ref": "account.connector"}))
+	require.EqualError(t, diff(map[string]interface{}{
+		"connector_ref": "org.connector",
+		"credentials":   []interface{}{map[string]interface{}{"password_ref": "org.password"}},
+	}), `connector_ref: "org.connector" references an organization level entity, which can't be used by an account level resource
+credentials.0.password_ref: "org.password" references an organization level entity, which can't be used by an account level resource`)
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/antihax/optional"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -37,6 +39,36 @@ func GetTagsSchema(flag SchemaFlagType) *schema.Schema {
 	return s
 }
 
+// GetTagsMapSchema returns the tags_map attribute, an alternative to the tags set taking the tags as a map so that
+// keys and values are never parsed out of key:value strings.
+func GetTagsMapSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:      "Tags to associate with the resource, as a map of key to value. Use it instead of `tags` when the values contain colons.",
+		Type:             schema.TypeMap,
+		Optional:         true,
+		ConflictsWith:    []string{"tags"},
+		ValidateDiagFunc: validateTagsMap,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func validateTagsMap(i interface{}, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for k := range i.(map[string]interface{}) {
+		if strings.Contains(k, ":") {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Invalid tag key %q", k),
+				Detail:        "Tag keys must not contain colons.",
+				AttributePath: p,
+			})
+		}
+	}
+	return diags
+}
+
 func GetIdentifierSchema(flag SchemaFlagType) *schema.Schema {
 	s := &schema.Schema{
 		Description: "Unique identifier of the resource.",
@@ -45,6 +77,7 @@ func GetIdentifierSchema(flag SchemaFlagType) *schema.Schema {
 
 	if flag == SchemaFlagTypes.Required {
 		s.ForceNew = true
+		s.ValidateDiagFunc = ValidateIdentifier
 	}
 
 	SetSchemaFlagType(s, flag)
@@ -105,6 +138,9 @@ func SetCommonResourceSchema(s map[string]*schema.Schema) {
 	s["description"] = GetDescriptionSchema(SchemaFlagTypes.Optional)
 	s["name"] = GetNameSchema(SchemaFlagTypes.Required)
 	s["tags"] = GetTagsSchema(SchemaFlagTypes.Optional)
+	s["tags"].ConflictsWith = []string{"tags_map"}
+	s["tags_map"] = GetTagsMapSchema()
+	s["tags_all"] = GetTagsAllSchema()
 }
 
 // SetCommonDataSourceSchema sets the default schema objects used for most data sources.
@@ -154,22 +190,46 @@ func SetOptionalOrgAndProjectLevelDataSourceSchema(s map[string]*schema.Schema)
 	s["project_id"] = GetProjectIdSchema(SchemaFlagTypes.Optional)
 }
 
+// GetDefaultableOrgIdSchema returns the org_id schema used by scoped resources, which falls back to the
+// provider level default_org_id when it is not set. It is computed so that SetScopeDefaults can set the default.
+func GetDefaultableOrgIdSchema() *schema.Schema {
+	s := GetOrgIdSchema(SchemaFlagTypes.Optional)
+	s.Description = "Unique identifier of the organization. Defaults to the provider level `default_org_id` when not set."
+	s.Computed = true
+	return s
+}
+
+// GetDefaultableProjectIdSchema returns the project_id schema used by scoped resources, which falls back to the
+// provider level default_project_id when it is not set. It is computed so that SetScopeDefaults can set the default.
+func GetDefaultableProjectIdSchema() *schema.Schema {
+	s := GetProjectIdSchema(SchemaFlagTypes.Optional)
+	s.Description = "Unique identifier of the project. Defaults to the provider level `default_project_id` when not set and the resource belongs to the default organization."
+	s.Computed = true
+	return s
+}
+
 // SetOrgLevelResourceSchema sets the default schema objects used for org level resources.
 func SetOrgLevelResourceSchema(s map[string]*schema.Schema) {
 	SetCommonResourceSchema(s)
-	s["org_id"] = GetOrgIdSchema(SchemaFlagTypes.Required)
+	s["org_id"] = GetDefaultableOrgIdSchema()
+	s["org_id"].ForceNew = true
 }
 
 // SetProjectLevelResourceSchema sets the default schema objects used for project level resources.
 func SetProjectLevelResourceSchema(s map[string]*schema.Schema) {
-	SetOrgLevelResourceSchema(s)
-	s["project_id"] = GetProjectIdSchema(SchemaFlagTypes.Required)
+	SetCommonResourceSchema(s)
+	s["org_id"] = GetDefaultableOrgIdSchema()
+	s["org_id"].ForceNew = true
+	s["project_id"] = GetDefaultableProjectIdSchema()
+	s["project_id"].ForceNew = true
 }
 
+// SetMultiLevelResourceSchema sets the default schema objects used for resources that can be created at the
+// account, org or project level.
 func SetMultiLevelResourceSchema(s map[string]*schema.Schema) {
 	SetCommonResourceSchema(s)
-	s["org_id"] = GetOrgIdSchema(SchemaFlagTypes.Optional)
-	s["project_id"] = GetProjectIdSchema(SchemaFlagTypes.Optional)
+	s["org_id"] = GetDefaultableOrgIdSchema()
+	s["project_id"] = GetDefaultableProjectIdSchema()
 	s["project_id"].RequiredWith = []string{"org_id"}
 }
 
@@ -200,6 +260,14 @@ func BuildField(d *schema.ResourceData, field string) optional.String {
 	return optional.EmptyString()
 }
 
+// OptionalString returns the value as an optional API parameter, which is not sent when the value is empty.
+func OptionalString(v string) optional.String {
+	if v == "" {
+		return optional.EmptyString()
+	}
+	return optional.NewString(v)
+}
+
 func BuildFieldInt32(d *schema.ResourceData, field string) optional.Int32 {
 	if arr, ok := d.GetOk(field); ok {
 		return optional.NewInt32(int32(arr.(int)))
@@ -224,411 +292,224 @@ func BuildFieldBool(d *schema.ResourceData, field string) optional.Bool {
 }
 
 // PipelineResourceImporter defines the importer configuration for all pipeline level resources.
-var PipelineResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("pipeline_id", parts[2])
-		d.Set("identifier", parts[3])
-		d.SetId(parts[3])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
-
-var DBInstanceResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("schema", parts[2])
-		d.Set("identifier", parts[3])
-		d.SetId(parts[3])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
-
-var TriggerResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("target_id", parts[2])
-		d.Set("identifier", parts[3])
-		d.SetId(parts[3])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
-
-var EnvRelatedResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		partCount := len(parts)
-		isAccountEntity := partCount == 2
-		isOrgEntity := partCount == 3
-		isProjectEntity := partCount == 4
-		if isAccountEntity {
-			d.Set("env_id", parts[0])
-			d.Set("identifier", parts[1])
-			d.SetId(parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-		if isOrgEntity {
-			d.Set("org_id", parts[0])
-			d.Set("env_id", parts[1])
-			d.Set("identifier", parts[2])
-			d.SetId(parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-		if isProjectEntity {
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("env_id", parts[2])
-			d.Set("identifier", parts[3])
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
-
+// The id used for the import should be in the format <org_id>/<project_id>/<pipeline_id>/<identifier>[@<branch>],
+// or <pipeline_id>/<identifier>[@<branch>] when default_org_id and default_project_id are set on the provider.
+var PipelineResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"pipeline_id", "identifier"},
+	Project: true,
+	Branch:  true,
+})
+
+// DBInstanceResourceImporter defines the importer configuration for database instances.
+// The id used for the import should be in the format <org_id>/<project_id>/<schema>/<identifier>
+var DBInstanceResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"schema", "identifier"},
+	Project: true,
+})
+
+// TriggerResourceImporter defines the importer configuration for triggers.
+// The id used for the import should be in the format <org_id>/<project_id>/<target_id>/<identifier>,
+// or <target_id>/<identifier> when default_org_id and default_project_id are set on the provider.
+var TriggerResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"target_id", "identifier"},
+	Project: true,
+})
+
+// EnvRelatedResourceImporter defines the importer configuration for the resources belonging to an environment.
+// The format used for the id is as follows:
+//   - Account Level: <env_id>/<identifier>
+//   - Org Level: <org_id>/<env_id>/<identifier>
+//   - Project Level: <org_id>/<project_id>/<env_id>/<identifier>
+var EnvRelatedResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"env_id", "identifier"},
+	Account: true,
+	Org:     true,
+	Project: true,
+})
+
+// ServiceOverrideResourceImporter defines the importer configuration for the service overrides of an environment.
+// The id used for the import should be in the format [<org_id>/[<project_id>/]]<env_id>
 var ServiceOverrideResourceImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		partCount := len(parts)
-		isAccountEntity := partCount == 1
-		isOrgEntity := partCount == 2
-		isProjectEntity := partCount == 3
-		if isAccountEntity {
-			d.Set("env_id", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-		if isOrgEntity {
-			d.Set("org_id", parts[0])
-			d.Set("env_id", parts[1])
-			return []*schema.ResourceData{d}, nil
+		id, err := ParseImportId(d.Id(), ImportIdFormat{Fields: []string{"env_id"}, Account: true, Org: true, Project: true}, meta)
+		if err != nil {
+			return nil, err
 		}
-		if isProjectEntity {
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("env_id", parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
+		id.setScope(d)
+		d.Set("env_id", id.Parts[0])
+		return []*schema.ResourceData{d}, nil
 	},
 }
 
+// ServiceOverrideV2ResourceImporter defines the importer configuration for overrides.
+// The id used for the import should be in the format [<org_id>/[<project_id>/]]<identifier>
 var ServiceOverrideV2ResourceImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		partCount := len(parts)
-		isAccountEntity := partCount == 1
-		isOrgEntity := partCount == 2
-		isProjectEntity := partCount == 3
-		if isAccountEntity {
-			d.SetId(parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-		if isOrgEntity {
-			d.Set("org_id", parts[0])
-			d.SetId(parts[1])
-			return []*schema.ResourceData{d}, nil
+		id, err := ParseImportId(d.Id(), ImportIdFormat{Fields: []string{"identifier"}, Account: true, Org: true, Project: true}, meta)
+		if err != nil {
+			return nil, err
 		}
-		if isProjectEntity {
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.SetId(parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
+		id.setScope(d)
+		d.SetId(id.Parts[0])
+		return []*schema.ResourceData{d}, nil
 	},
 }
 
+// UserResourceImporter defines the importer configuration for users.
+// The id used for the import should be in the format <email>[/<org_id>[/<project_id>]]
 var UserResourceImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("email", parts[0])
-		if len(parts) > 1 {
-			d.Set("org_id", parts[1])
-		}
-		if len(parts) > 2 {
-			d.Set("project_id", parts[2])
+		id, err := parseTrailingScopeImportId(d.Id(), "email")
+		if err != nil {
+			return nil, err
 		}
+		d.Set("email", id.Parts[0])
+		id.setScope(d)
 		return []*schema.ResourceData{d}, nil
 	},
 }
 
 // ProjectResourceImporter defines the importer configuration for all project level resources.
-// The id used for the import should be in the format <org_id>/<project_id>/<identifier>
-// The id used for the import should be in the format <org_id>/<project_id>/<identifier>/<branch>
+// The id used for the import should be in the format <org_id>/<project_id>/<identifier>[@<branch>]
+// When default_org_id and default_project_id are set on the provider the id can also be just <identifier>[@<branch>].
+// The deprecated <org_id>/<project_id>/<identifier>/<branch> format is still accepted.
 var ProjectResourceImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("identifier", parts[2])
-		d.SetId(parts[2])
-
-		if len(parts) == 4 {
-			d.Set("git_details", []interface{}{map[string]interface{}{"branch_name": parts[3]}})
-		}
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
-
-// GitopsAgentResourceImporter defines the importer configuration for all project level gitops agent resources.
-// The id used for the import should be in the format <org_id>/<project_id>/<identifier>/<agentId>
-var GitopsAgentResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		if len(parts) == 2 { //Account level
-			d.Set("agent_id", parts[0])
-			d.Set("identifier", parts[1])
-			d.SetId(parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 3 { //Org level
-			d.Set("org_id", parts[0])
-			d.Set("agent_id", parts[1])
-			d.Set("identifier", parts[2])
-			d.SetId(parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 4 { //Project level
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("agent_id", parts[2])
-			d.Set("identifier", parts[3])
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
+		if parts := strings.Split(d.Id(), "/"); len(parts) == 4 && !strings.Contains(d.Id(), "@") {
+			d.SetId(FormatImportId(parts[0], parts[1], parts[3], parts[2]))
 		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
+		return projectResourceImporter.State(d, meta)
 	},
 }
 
-var GitopsAgentApplicationImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		if len(parts) == 4 { //Project level
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("agent_id", parts[2])
-			d.Set("name", parts[3])
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
+var projectResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"identifier"},
+	Project: true,
+	Branch:  true,
+})
 
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
+// GitopsAgentResourceImporter defines the importer configuration for all gitops agent level resources.
+// The format used for the id is as follows:
+//   - Account Level: <agent_id>/<identifier>
+//   - Org Level: <org_id>/<agent_id>/<identifier>
+//   - Project Level: <org_id>/<project_id>/<agent_id>/<identifier>
+var GitopsAgentResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"agent_id", "identifier"},
+	Account: true,
+	Org:     true,
+	Project: true,
+})
+
+// GitopsAgentApplicationImporter defines the importer configuration for gitops applications.
+// The id used for the import should be in the format <org_id>/<project_id>/<agent_id>/<name>
+var GitopsAgentApplicationImporter = importResource(ImportIdFormat{
+	Fields:  []string{"agent_id", "name"},
+	Project: true,
+})
 
 // GitopsAppProjectMappingImporter defines the importer configuration for app project mapping.
-// The id used for the import should be in the format <org_id>/<project_id>/<identifier>/<argo_project_name>\
+// The id used for the import should be in the format <org_id>/<project_id>/<agent_id>/<argo_project_name>
 // It is used always at project level.
-var GitopsAppProjectMappingImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-
-		// Approject mapping always has 4 parts
-		if len(parts) == 4 { //Project level
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("agent_id", parts[2])
-			d.Set("argo_project_name", parts[3])
-			// During import we are using argo_project_name as identifier not the actual identifier which is mongo id
-			// that way we are not fetching mapping by mongo id but by argo_project_name, agent_id, account_id, org_id and project_id.
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
-
-// GitopsAgentResourceImporter defines the importer configuration for all project level gitops agent resources.
-// The id used for the import should be in the format <agent_id>/<query_name>
-var GitopsAgentProjectImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		if len(parts) == 2 { //Account level
-			d.Set("agent_id", parts[0])
-			d.Set("query_name", parts[1])
-			d.SetId(parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 3 { //Org level
-			d.Set("org_id", parts[0])
-			d.Set("agent_id", parts[1])
-			d.Set("query_name", parts[2])
-			d.SetId(parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 4 { //Project level
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("agent_id", parts[2])
-			d.Set("query_name", parts[3])
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
-
-// The id used for the import should be in the format <org_id>/<project_id>/<repoIdentifier>/<identifier>
-var RepoRuleResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		if len(parts) == 2 { //Account level
-			d.Set("repo_identifier", parts[0])
-			d.Set("identifier", parts[1])
-			d.SetId(parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 3 { //Org level
-			d.Set("org_id", parts[0])
-			d.Set("repo_identifier", parts[1])
-			d.Set("identifier", parts[2])
-			d.SetId(parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 4 { //Project level
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("repo_identifier", parts[2])
-			d.Set("identifier", parts[3])
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
-
+// During import we are using argo_project_name as identifier not the actual identifier which is mongo id
+// that way we are not fetching mapping by mongo id but by argo_project_name, agent_id, account_id, org_id and project_id.
+var GitopsAppProjectMappingImporter = importResource(ImportIdFormat{
+	Fields:  []string{"agent_id", "argo_project_name"},
+	Project: true,
+})
+
+// GitopsAgentProjectImporter defines the importer configuration for gitops projects.
+// The id used for the import should be in the format [<org_id>/[<project_id>/]]<agent_id>/<query_name>
+var GitopsAgentProjectImporter = importResource(ImportIdFormat{
+	Fields:  []string{"agent_id", "query_name"},
+	Account: true,
+	Org:     true,
+	Project: true,
+})
+
+// RepoRuleResourceImporter defines the importer configuration for repository rules.
+// The id used for the import should be in the format [<org_id>/[<project_id>/]]<repo_identifier>/<identifier>
+var RepoRuleResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"repo_identifier", "identifier"},
+	Account: true,
+	Org:     true,
+	Project: true,
+})
+
+// GitopsRepoCertResourceImporter defines the importer configuration for gitops repository certificates.
+// The id used for the import should be in the format <agent_id>/<identifier>
 var GitopsRepoCertResourceImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("agent_id", parts[0])
-		d.SetId(parts[1])
+		id, err := ParseImportId(d.Id(), ImportIdFormat{Fields: []string{"agent_id", "identifier"}, Account: true}, meta)
+		if err != nil {
+			return nil, err
+		}
+		d.Set("agent_id", id.Parts[0])
+		d.SetId(id.Parts[1])
 		return []*schema.ResourceData{d}, nil
 	},
 }
 
 // OrgResourceImporter defines the importer configuration for all organization level resources.
-// The id used for the import should be in the format <org_id>/<identifier>
-var OrgResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("identifier", parts[1])
-		d.SetId(parts[1])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
+// The id used for the import should be in the format <org_id>/<identifier>, or just <identifier>
+// when default_org_id is set on the provider.
+var OrgResourceImporter = importResource(ImportIdFormat{
+	Fields: []string{"identifier"},
+	Org:    true,
+})
 
 // MultiLevelResourceImporter defines the importer configuration for all multi level resources.
 // The format used for the id is as follows:
 //   - Account Level: <identifier>
 //   - Org Level: <org_id>/<identifier>
 //   - Project Level: <org_id>/<project_id>/<identifier>
-var MultiLevelResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-
-		partCount := len(parts)
-		isAccountConnector := partCount == 1
-		isOrgConnector := partCount == 2
-		isProjectConnector := partCount == 3
-
-		if isAccountConnector {
-			d.SetId(parts[0])
-			d.Set("identifier", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isOrgConnector {
-			d.SetId(parts[1])
-			d.Set("identifier", parts[1])
-			d.Set("org_id", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isProjectConnector {
-			d.SetId(parts[2])
-			d.Set("identifier", parts[2])
-			d.Set("project_id", parts[1])
-			d.Set("org_id", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
-
+var MultiLevelResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"identifier"},
+	Account: true,
+	Org:     true,
+	Project: true,
+})
+
+// MultiLevelGitResourceImporter defines the importer configuration for the multi level resources that can be
+// stored in git. The ids are the ones of MultiLevelResourceImporter, optionally followed by @<branch> to import the
+// resource from a branch other than the default one.
+var MultiLevelGitResourceImporter = importResource(ImportIdFormat{
+	Fields:  []string{"identifier"},
+	Account: true,
+	Org:     true,
+	Project: true,
+	Branch:  true,
+})
+
+// MultiLevelFilterImporter defines the importer configuration for filters.
+// The format used for the id is as follows:
+//   - Account Level: <identifier>/<type>
+//   - Org Level: <org_id>/<identifier>/<type>
+//   - Project Level: <org_id>/<project_id>/<identifier>/<type>
 var MultiLevelFilterImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-
-		partCount := len(parts)
-		isAccountConnector := partCount == 2
-		isOrgConnector := partCount == 3
-		isProjectConnector := partCount == 4
-
-		if isAccountConnector {
-			d.SetId(parts[0])
-			d.Set("identifier", parts[0])
-			d.Set("type", parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isOrgConnector {
-			d.SetId(parts[1])
-			d.Set("identifier", parts[1])
-			d.Set("org_id", parts[0])
-			d.Set("type", parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isProjectConnector {
-			d.SetId(parts[2])
-			d.Set("identifier", parts[2])
-			d.Set("project_id", parts[1])
-			d.Set("org_id", parts[0])
-			d.Set("type", parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
+		id, err := ParseImportId(d.Id(), ImportIdFormat{Fields: []string{"identifier", "type"}, Account: true, Org: true, Project: true}, meta)
+		if err != nil {
+			return nil, err
+		}
+		id.setScope(d)
+		d.Set("identifier", id.Parts[0])
+		d.Set("type", id.Parts[1])
+		d.SetId(id.Parts[0])
+		return []*schema.ResourceData{d}, nil
 	},
 }
 
+// GitWebhookResourceImporter defines the importer configuration for git webhooks.
+// The id used for the import should be in the format <identifier>[/<org_id>[/<project_id>]]
 var GitWebhookResourceImporter = &schema.ResourceImporter{
 	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("identifier", parts[0])
-		if len(parts) > 1 {
-			d.Set("org_id", parts[1])
-		}
-		if len(parts) > 2 {
-			d.Set("project_id", parts[2])
+		id, err := parseTrailingScopeImportId(d.Id(), "identifier")
+		if err != nil {
+			return nil, err
 		}
-		d.SetId(parts[0])
+		d.Set("identifier", id.Parts[0])
+		id.setScope(d)
+		d.SetId(id.Parts[0])
 
 		return []*schema.ResourceData{d}, nil
 	},
@@ -0,0 +1,124 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ScopeLevel is the level at which a resource is created, which selects the provider defaults it inherits.
+type ScopeLevel int
+
+var ScopeLevels = struct {
+	Multi   ScopeLevel
+	Org     ScopeLevel
+	Project ScopeLevel
+}{
+	Multi:   0,
+	Org:     1,
+	Project: 2,
+}
+
+// SetScopeDefaults adds a CustomizeDiff that falls back to the provider level default_org_id and
+// default_project_id when org_id or project_id are not set in the configuration. It must be called on
+// resources whose schema was built with the scoped resource schema helper of the same level.
+func SetScopeDefaults(r *schema.Resource, level ScopeLevel) {
+	prependCustomizeDiff(r, scopeDefaultsCustomizeDiff(level))
+}
+
+// prependCustomizeDiff runs f before the CustomizeDiff already defined on the resource, if any.
+func prependCustomizeDiff(r *schema.Resource, f schema.CustomizeDiffFunc) {
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = f
+		return
+	}
+	r.CustomizeDiff = customdiff.Sequence(f, r.CustomizeDiff)
+}
+
+// appendCustomizeDiff runs f after the CustomizeDiff already defined on the resource, if any.
+func appendCustomizeDiff(r *schema.Resource, f schema.CustomizeDiffFunc) {
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = f
+		return
+	}
+	r.CustomizeDiff = customdiff.Sequence(r.CustomizeDiff, f)
+}
+
+func scopeDefaultsCustomizeDiff(level ScopeLevel) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		defaultOrgId, defaultProjectId := getScopeDefaults(meta)
+
+		orgId, projectId := "", ""
+		orgSet := isScopeAttributeSet(d, "org_id")
+		if orgSet {
+			orgId = d.Get("org_id").(string)
+		}
+		projectSet := level != ScopeLevels.Org && isScopeAttributeSet(d, "project_id")
+		if projectSet {
+			projectId = d.Get("project_id").(string)
+		}
+
+		orgId, projectId, err := resolveScope(level, orgSet, orgId, projectSet, projectId, defaultOrgId, defaultProjectId)
+		if err != nil {
+			return err
+		}
+
+		// The attributes are computed so that they can inherit the defaults, which means that removing them from the
+		// configuration would not show up in the plan: the resolved value is set instead, empty without a default.
+		if !orgSet {
+			if err := d.SetNew("org_id", orgId); err != nil {
+				return err
+			}
+		}
+		if level != ScopeLevels.Org && !projectSet {
+			if err := d.SetNew("project_id", projectId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// resolveScope returns the effective org and project identifiers of a resource.
+// The default project is only inherited together with the default organization, except for project level
+// resources which also inherit it when their org_id is explicitly set to the default organization.
+func resolveScope(level ScopeLevel, orgSet bool, orgId string, projectSet bool, projectId string, defaultOrgId string, defaultProjectId string) (string, string, error) {
+	if !orgSet {
+		orgId = defaultOrgId
+	}
+
+	if level != ScopeLevels.Org && !projectSet && orgId != "" && (!orgSet || (level == ScopeLevels.Project && orgId == defaultOrgId)) {
+		projectId = defaultProjectId
+	}
+
+	if level != ScopeLevels.Multi && orgId == "" {
+		return "", "", fmt.Errorf("org_id is required: set it on the resource or configure default_org_id on the provider")
+	}
+	if level == ScopeLevels.Project && projectId == "" {
+		return "", "", fmt.Errorf("project_id is required: set it on the resource or configure default_project_id on the provider")
+	}
+	if projectId != "" && orgId == "" {
+		return "", "", fmt.Errorf("org_id is required when project_id is set")
+	}
+
+	return orgId, projectId, nil
+}
+
+func isScopeAttributeSet(d *schema.ResourceDiff, key string) bool {
+	if raw := d.GetRawConfig(); !raw.IsNull() && raw.IsKnown() {
+		return !raw.GetAttr(key).IsNull()
+	}
+	return d.Get(key).(string) != ""
+}
+
+func getScopeDefaults(meta interface{}) (string, string) {
+	session, ok := meta.(*internal.Session)
+	if !ok || session == nil {
+		return "", ""
+	}
+	return session.DefaultOrgId, session.DefaultProjectId
+}
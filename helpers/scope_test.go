@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveScope_ProjectLevelDefaults(t *testing.T) {
+	orgId, projectId, err := resolveScope(ScopeLevels.Project, false, "", false, "", "default_org", "default_project")
+	require.NoError(t, err)
+	require.Equal(t, "default_org", orgId)
+	require.Equal(t, "default_project", projectId)
+
+	orgId, projectId, err = resolveScope(ScopeLevels.Project, true, "default_org", false, "", "default_org", "default_project")
+	require.NoError(t, err)
+	require.Equal(t, "default_org", orgId)
+	require.Equal(t, "default_project", projectId)
+
+	_, _, err = resolveScope(ScopeLevels.Project, true, "other_org", false, "", "default_org", "default_project")
+	require.Error(t, err)
+
+	_, _, err = resolveScope(ScopeLevels.Project, false, "", false, "", "", "")
+	require.Error(t, err)
+}
+
+func TestResolveScope_OrgLevelDefaults(t *testing.T) {
+	orgId, projectId, err := resolveScope(ScopeLevels.Org, false, "", false, "", "default_org", "default_project")
+	require.NoError(t, err)
+	require.Equal(t, "default_org", orgId)
+	require.Equal(t, "", projectId)
+
+	_, _, err = resolveScope(ScopeLevels.Org, false, "", false, "", "", "")
+	require.Error(t, err)
+}
+
+func TestResolveScope_MultiLevelDefaults(t *testing.T) {
+	orgId, projectId, err := resolveScope(ScopeLevels.Multi, false, "", false, "", "default_org", "default_project")
+	require.NoError(t, err)
+	require.Equal(t, "default_org", orgId)
+	require.Equal(t, "default_project", projectId)
+
+	// An explicit org_id does not inherit the default project.
+	orgId, projectId, err = resolveScope(ScopeLevels.Multi, true, "default_org", false, "", "default_org", "default_project")
+	require.NoError(t, err)
+	require.Equal(t, "default_org", orgId)
+	require.Equal(t, "", projectId)
+
+	// An explicitly empty org_id keeps the resource at the account level.
+	orgId, projectId, err = resolveScope(ScopeLevels.Multi, true, "", false, "", "default_org", "default_project")
+	require.NoError(t, err)
+	require.Equal(t, "", orgId)
+	require.Equal(t, "", projectId)
+
+	orgId, projectId, err = resolveScope(ScopeLevels.Multi, false, "", false, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "", orgId)
+	require.Equal(t, "", projectId)
+
+	_, _, err = resolveScope(ScopeLevels.Multi, true, "", true, "project", "", "")
+	require.Error(t, err)
+}
+
+func TestSetScopeDefaults(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	SetMultiLevelResourceSchema(r.Schema)
+	SetScopeDefaults(r, ScopeLevels.Multi)
+
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+	plan := func(state *terraform.InstanceState, config map[string]interface{}, meta interface{}) *terraform.InstanceDiff {
+		config["identifier"] = "test"
+		config["name"] = "test"
+		if state != nil {
+			// The raw configuration tells unset attributes apart from the computed values kept in the state.
+			values := map[string]cty.Value{}
+			for k, v := range config {
+				values[k] = cty.StringVal(v.(string))
+			}
+			raw, err := r.CoreConfigSchema().CoerceValue(cty.ObjectVal(values))
+			require.NoError(t, err)
+			state.RawConfig = raw
+		}
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+		require.NoError(t, err)
+		return diff
+	}
+	state := func(orgId string, projectId string) *terraform.InstanceState {
+		return &terraform.InstanceState{ID: "test", Attributes: map[string]string{
+			"id":         "test",
+			"identifier": "test",
+			"name":       "test",
+			"org_id":     orgId,
+			"project_id": projectId,
+			"tags_all.#": "0",
+		}}
+	}
+
+	diff := plan(nil, map[string]interface{}{}, session)
+	require.Equal(t, "default_org", diff.Attributes["org_id"].New)
+	require.Equal(t, "default_project", diff.Attributes["project_id"].New)
+
+	diff = plan(nil, map[string]interface{}{"org_id": "org"}, session)
+	require.Equal(t, "org", diff.Attributes["org_id"].New)
+
+	// Removing the attributes without defaults moves the resource back to the account level.
+	diff = plan(state("org", "project"), map[string]interface{}{}, nil)
+	require.Equal(t, "", diff.Attributes["org_id"].New)
+	require.Equal(t, "", diff.Attributes["project_id"].New)
+	require.False(t, diff.RequiresNew())
+
+	diff = plan(state("default_org", "default_project"), map[string]interface{}{}, session)
+	require.Nil(t, diff)
+}
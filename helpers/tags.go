@@ -4,13 +4,16 @@ import (
 	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// ExpandTags converts tags in the key:value format to the map sent to the API. Tags are split on their first
+// colon so that values containing colons, like urls, are kept intact.
 func ExpandTags(tags []interface{}) map[string]string {
 	result := map[string]string{}
 
 	for _, tag := range tags {
-		parts := strings.Split(tag.(string), ":")
+		parts := strings.SplitN(tag.(string), ":", 2)
 		if len(parts) == 1 {
 			parts = append(parts, "")
 		}
@@ -20,6 +23,8 @@ func ExpandTags(tags []interface{}) map[string]string {
 	return result
 }
 
+// FlattenTags converts the tags returned by the API to the key:value format. Tags without a value are flattened
+// to their key.
 func FlattenTags(tags map[string]string) []string {
 	var result []string
 	for k, v := range tags {
@@ -32,6 +37,40 @@ func FlattenTags(tags map[string]string) []string {
 	return result
 }
 
+// ExpandTagsMap converts the tags_map attribute to the map sent to the API.
+func ExpandTagsMap(tags map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for k, v := range tags {
+		result[k] = v.(string)
+	}
+	return result
+}
+
+// ExpandResourceTags returns the tags set on a resource built with SetCommonResourceSchema, either with tags or
+// with tags_map, in the key:value format. It also reports whether they are set with tags_map, so that the tags
+// returned by the API can be set back with FlattenResourceTags into the attribute they were configured with.
+func ExpandResourceTags(d interface{ Get(string) interface{} }) ([]interface{}, bool) {
+	tags := d.Get("tags").(*schema.Set).List()
+	tagsMap, _ := d.Get("tags_map").(map[string]interface{})
+	if len(tagsMap) == 0 {
+		return tags, false
+	}
+	for _, tag := range FlattenTags(ExpandTagsMap(tagsMap)) {
+		tags = append(tags, tag)
+	}
+	return tags, true
+}
+
+// FlattenResourceTags sets the tags returned by the API into tags, or into tags_map when isMap is set.
+func FlattenResourceTags(d *schema.ResourceData, tags map[string]string, isMap bool) {
+	if isMap {
+		d.Set("tags", nil)
+		d.Set("tags_map", tags)
+		return
+	}
+	d.Set("tags", FlattenTags(tags))
+}
+
 func ExpandScopeSelector(scopeSelectors []interface{}) []nextgen.ScopeSelector {
 	var result []nextgen.ScopeSelector
 	for _, scopeSelector := range scopeSelectors {
@@ -0,0 +1,42 @@
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandTags(t *testing.T) {
+	tags := helpers.ExpandTags([]interface{}{"url:https://harness.io:443", "arn:aws:iam::123456789012:role/test", "empty"})
+	require.Equal(t, map[string]string{
+		"url":   "https://harness.io:443",
+		"arn":   "aws:iam::123456789012:role/test",
+		"empty": "",
+	}, tags)
+
+	require.ElementsMatch(t, []string{"url:https://harness.io:443", "arn:aws:iam::123456789012:role/test", "empty"}, helpers.FlattenTags(tags))
+}
+
+func TestExpandResourceTags(t *testing.T) {
+	s := map[string]*schema.Schema{}
+	helpers.SetCommonResourceSchema(s)
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{"tags": []interface{}{"env:dev"}})
+	tags, isMap := helpers.ExpandResourceTags(d)
+	require.Equal(t, []interface{}{"env:dev"}, tags)
+	require.False(t, isMap)
+
+	helpers.FlattenResourceTags(d, map[string]string{"env": "prod"}, isMap)
+	require.Equal(t, []interface{}{"env:prod"}, d.Get("tags").(*schema.Set).List())
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{"tags_map": map[string]interface{}{"url": "https://harness.io:443"}})
+	tags, isMap = helpers.ExpandResourceTags(d)
+	require.Equal(t, []interface{}{"url:https://harness.io:443"}, tags)
+	require.True(t, isMap)
+
+	helpers.FlattenResourceTags(d, map[string]string{"url": "https://app.harness.io:443"}, isMap)
+	require.Equal(t, map[string]interface{}{"url": "https://app.harness.io:443"}, d.Get("tags_map"))
+	require.Empty(t, d.Get("tags").(*schema.Set).List())
+}
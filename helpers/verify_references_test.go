@@ -0,0 +1,82 @@
+package helpers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/provider"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/secret"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyReferences(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	server.SeedProject(t, "org", "project")
+	server.Create(t, secret.ResourceSecretText(), map[string]interface{}{
+		"identifier":                "secret",
+		"name":                      "Secret",
+		"org_id":                    "org",
+		"project_id":                "project",
+		"secret_manager_identifier": "harnessSecretManager",
+		"value_type":                "Inline",
+		"value":                     "value",
+	})
+
+	r := provider.Provider("dev")().ResourcesMap["harness_platform_connector_datadog"]
+	var state *terraform.InstanceState
+	plan := func(applicationKeyRef string) error {
+		_, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"identifier":          "datadog",
+			"name":                "Datadog",
+			"org_id":              "org",
+			"project_id":          "project",
+			"url":                 "https://datadoghq.com",
+			"application_key_ref": applicationKeyRef,
+			"api_key_ref":         "secret",
+		}), session)
+		return err
+	}
+
+	// References are only verified when verify_references is enabled.
+	require.NoError(t, plan("typo"))
+
+	reads := func() int {
+		n := 0
+		for _, request := range server.Requests() {
+			if request == "GET /ng/api/v2/secrets/secret" {
+				n++
+			}
+		}
+		return n
+	}
+	session.VerifyReferences = true
+	require.NoError(t, plan("secret"))
+	require.EqualError(t, plan("typo"), `application_key_ref: the secret "typo" does not exist in the project org/project`)
+	require.EqualError(t, plan("org.secret"), `application_key_ref: the secret "org.secret" does not exist in the organization org`)
+
+	// Each entity is only read once per session.
+	before := reads()
+	require.NotZero(t, before)
+	require.NoError(t, plan("secret"))
+	require.EqualError(t, plan("org.secret"), `application_key_ref: the secret "org.secret" does not exist in the organization org`)
+	require.Equal(t, before, reads())
+
+	// The unchanged references of existing resources are not verified.
+	state = &terraform.InstanceState{ID: "datadog", Attributes: map[string]string{
+		"id":                  "datadog",
+		"identifier":          "datadog",
+		"name":                "Datadog",
+		"org_id":              "org",
+		"project_id":          "project",
+		"url":                 "https://datadoghq.com",
+		"application_key_ref": "deleted",
+		"api_key_ref":         "secret",
+	}}
+	require.NoError(t, plan("deleted"))
+	require.EqualError(t, plan("typo"), `application_key_ref: the secret "typo" does not exist in the project org/project`)
+}
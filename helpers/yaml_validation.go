@@ -0,0 +1,266 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// YamlKind is the kind of Harness entity described by a YAML, which selects the schema it is validated against.
+type YamlKind string
+
+var YamlKinds = struct {
+	Pipeline YamlKind
+	Template YamlKind
+	InputSet YamlKind
+	Trigger  YamlKind
+}{
+	Pipeline: "pipeline",
+	Template: "template",
+	InputSet: "input_set",
+	Trigger:  "trigger",
+}
+
+// yamlSchemaFiles are the JSON schemas of the YAML of the entities, named after their kind. They describe the
+// structure of the entities and the fields Harness requires, not every field of every type of stage or step.
+//
+//go:embed schemas/*.json
+var yamlSchemaFiles embed.FS
+
+var (
+	yamlSchemasOnce sync.Once
+	yamlSchemas     map[YamlKind]*jsonschema.Schema
+	yamlSchemasErr  error
+)
+
+func getYamlSchema(kind YamlKind) (*jsonschema.Schema, error) {
+	yamlSchemasOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		compiler.Draft = jsonschema.Draft7
+		files, err := yamlSchemaFiles.ReadDir("schemas")
+		if err != nil {
+			yamlSchemasErr = err
+			return
+		}
+		for _, f := range files {
+			data, err := yamlSchemaFiles.ReadFile("schemas/" + f.Name())
+			if err != nil {
+				yamlSchemasErr = err
+				return
+			}
+			if err := compiler.AddResource(f.Name(), bytes.NewReader(data)); err != nil {
+				yamlSchemasErr = err
+				return
+			}
+		}
+
+		yamlSchemas = map[YamlKind]*jsonschema.Schema{}
+		for _, k := range []YamlKind{YamlKinds.Pipeline, YamlKinds.Template, YamlKinds.InputSet, YamlKinds.Trigger} {
+			s, err := compiler.Compile(string(k) + ".json")
+			if err != nil {
+				yamlSchemasErr = err
+				return
+			}
+			yamlSchemas[k] = s
+		}
+	})
+	if yamlSchemasErr != nil {
+		return nil, fmt.Errorf("error compiling the bundled YAML schemas: %w", yamlSchemasErr)
+	}
+	return yamlSchemas[kind], nil
+}
+
+// ValidateYaml validates the YAML of an entity against the schema of its kind bundled with the provider. The
+// error lists the problems found with the line they are on.
+func ValidateYaml(kind YamlKind, s string) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(s), &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("the YAML is empty")
+	}
+
+	var v interface{}
+	if err := doc.Decode(&v); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("the YAML can't be converted to JSON: %w", err)
+	}
+	var instance interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&instance); err != nil {
+		return err
+	}
+
+	yamlSchema, err := getYamlSchema(kind)
+	if err != nil {
+		return err
+	}
+	err = yamlSchema.Validate(instance)
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+
+	type yamlError struct {
+		line    int
+		message string
+	}
+	var errs []yamlError
+	for _, e := range leafValidationErrors(validationErr) {
+		tokens := jsonPointerTokens(e.InstanceLocation)
+		line := yamlLineAt(doc.Content[0], tokens)
+		errs = append(errs, yamlError{line, fmt.Sprintf("line %d: %s: %s", line, yamlPath(tokens), e.Message)})
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].line < errs[j].line })
+
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.message
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
+
+// leafValidationErrors returns the validation errors without causes, which are the ones describing a problem.
+func leafValidationErrors(e *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(e.Causes) == 0 {
+		return []*jsonschema.ValidationError{e}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range e.Causes {
+		leaves = append(leaves, leafValidationErrors(cause)...)
+	}
+	return leaves
+}
+
+func jsonPointerTokens(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens
+}
+
+// yamlLineAt returns the line of the YAML at the path of a JSON pointer, which is the line of the key for the
+// values of a mapping, or 0.
+func yamlLineAt(node *yaml.Node, tokens []string) int {
+	line := node.Line
+	for _, token := range tokens {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					line, next = node.Content[i].Line, node.Content[i+1]
+				}
+			}
+			if next == nil {
+				return 0
+			}
+			node = next
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return 0
+			}
+			node = node.Content[i]
+			line = node.Line
+		default:
+			return 0
+		}
+	}
+	return line
+}
+
+// yamlPath formats the path of a JSON pointer like pipeline.stages[0].stage.
+func yamlPath(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		if _, err := strconv.Atoi(token); err == nil {
+			b.WriteString("[" + token + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(token)
+	}
+	if b.Len() == 0 {
+		return "(root)"
+	}
+	return b.String()
+}
+
+// SetYamlValidation validates at plan time the YAML attribute of a resource against the schema of its kind, so
+// that an invalid YAML fails the plan instead of the apply. When verify_yaml is enabled on the provider, the YAML
+// of the pipelines is also validated with the Harness API. YAML that is not known or not changed is not validated.
+func SetYamlValidation(r *schema.Resource, attribute string, kind YamlKind) {
+	appendCustomizeDiff(r, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown(attribute) {
+			return nil
+		}
+		s := d.Get(attribute).(string)
+		if s == "" || (d.Id() != "" && !d.HasChange(attribute)) {
+			return nil
+		}
+
+		if err := ValidateYaml(kind, s); err != nil {
+			return fmt.Errorf("%s is not a valid %s YAML:\n%s", attribute, strings.ReplaceAll(string(kind), "_", " "), err)
+		}
+
+		session, ok := meta.(*internal.Session)
+		if !ok || session == nil || !session.VerifyYaml || kind != YamlKinds.Pipeline {
+			return nil
+		}
+		orgId, projectId, ok := getReferenceScope(d, r.Schema)
+		if !ok {
+			return nil
+		}
+		if err := verifyPipelineYaml(ctx, session, s, orgId, projectId); err != nil {
+			return fmt.Errorf("%s was rejected by Harness: %s", attribute, err)
+		}
+		return nil
+	})
+}
+
+// verifyPipelineYaml validates the YAML of a pipeline with the Harness API. Only the errors reporting an invalid
+// YAML are returned, the other API errors are ignored so that the plan doesn't fail when Harness can't be reached.
+func verifyPipelineYaml(ctx context.Context, session *internal.Session, s string, orgId string, projectId string) error {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+	_, httpResp, err := c.PipelinesApi.PostPipeline1(ctx, s, c.AccountId, orgId, projectId)
+	if err == nil {
+		return nil
+	}
+
+	e := parseApiError(err, httpResp)
+	if e.statusCode != http.StatusBadRequest {
+		return nil
+	}
+	messages := []string{e.message}
+	for _, fe := range e.fieldErrors {
+		messages = append(messages, fmt.Sprintf("%s: %s", fe.field, fe.message))
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
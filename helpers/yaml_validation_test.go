@@ -0,0 +1,120 @@
+package helpers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/provider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateYaml(t *testing.T) {
+	require.NoError(t, helpers.ValidateYaml(helpers.YamlKinds.Pipeline, `
+pipeline:
+  name: pipeline
+  identifier: pipeline
+  projectIdentifier: project
+  orgIdentifier: org
+  tags: {}
+  stages:
+    - stage:
+        name: deploy
+        identifier: deploy
+        type: Custom
+        spec:
+          execution:
+            steps: []
+    - parallel:
+        - stage:
+            name: templated
+            identifier: templated
+            template:
+              templateRef: account.stage
+              versionLabel: v1
+`))
+	require.NoError(t, helpers.ValidateYaml(helpers.YamlKinds.InputSet, `
+inputSet:
+  name: input_set
+  identifier: input_set
+  pipeline:
+    identifier: pipeline
+    variables:
+      - name: key
+        type: String
+        value: value
+`))
+	require.NoError(t, helpers.ValidateYaml(helpers.YamlKinds.Template, `
+template:
+  name: template
+  identifier: template
+  versionLabel: v1
+  type: Stage
+  spec:
+    type: Custom
+    spec: {}
+`))
+
+	require.EqualError(t, helpers.ValidateYaml(helpers.YamlKinds.Pipeline, `
+pipeline:
+  name: pipeline
+   identifier: pipeline
+`), "yaml: line 4: mapping values are not allowed in this context")
+
+	require.EqualError(t, helpers.ValidateYaml(helpers.YamlKinds.Pipeline, `
+pipeline:
+  name: pipeline
+  identifier: my-pipeline
+  stages:
+    - stage:
+        name: deploy
+        identifier: deploy
+        spec: {}
+`), `line 4: pipeline.identifier: does not match pattern '^[a-zA-Z_][0-9a-zA-Z_$]{0,127}$'
+line 6: pipeline.stages[0].stage: missing properties: 'type'`)
+
+	require.EqualError(t, helpers.ValidateYaml(helpers.YamlKinds.Trigger, `
+trigger:
+  name: trigger
+`), "line 2: trigger: missing properties: 'identifier', 'source'")
+}
+
+func TestSetYamlValidation(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	r := provider.Provider("dev")().ResourcesMap["harness_platform_pipeline"]
+	plan := func(yaml string) error {
+		_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"identifier": "pipeline",
+			"name":       "pipeline",
+			"org_id":     "org",
+			"project_id": "project",
+			"yaml":       yaml,
+		}), session)
+		return err
+	}
+
+	require.NoError(t, plan(`
+pipeline:
+  name: pipeline
+  identifier: pipeline
+`))
+	require.EqualError(t, plan(`
+pipeline:
+  identifier: pipeline
+`), `yaml is not a valid pipeline YAML:
+line 2: pipeline: missing properties: 'name'`)
+
+	// The YAML is only validated with Harness when verify_yaml is enabled.
+	session.VerifyYaml = true
+	require.NoError(t, plan(`
+pipeline:
+  name: pipeline
+  identifier: pipeline
+`))
+	require.Contains(t, server.Requests(), "POST /pipeline/api/pipelines/validate-yaml-with-schema")
+}
@@ -1,7 +1,10 @@
 package helpers
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"gopkg.in/yaml.v3"
@@ -9,14 +12,136 @@ import (
 
 // YamlDiffSuppressFunction returns true if two content of yaml strings are identical.
 // That helps to avoid unnecessary changes in plan if the yaml format was changed only, but not the data.
+// The yamls are compared after being normalized with NormalizeYaml, so that the fields Harness adds to the
+// yaml of the entities it stores don't show as changes either.
 func YamlDiffSuppressFunction(k, old, new string, d *schema.ResourceData) bool {
-	var oldYaml, newYaml interface{}
-	if err := yaml.Unmarshal([]byte(old), &oldYaml); err != nil {
+	oldYaml, err := NormalizeYaml(old)
+	if err != nil {
 		return false
 	}
-	if err := yaml.Unmarshal([]byte(new), &newYaml); err != nil {
+	newYaml, err := NormalizeYaml(new)
+	if err != nil {
 		return false
 	}
 	return reflect.DeepEqual(oldYaml, newYaml)
+}
+
+// entityScopeFields are added by Harness to the yaml of the entities it stores with the scope of the entity, which
+// is already set with the org_id and project_id attributes of the resources.
+var entityScopeFields = map[string]bool{
+	"orgIdentifier":     true,
+	"projectIdentifier": true,
+}
+
+// unorderedLists are the lists whose order is not significant, mapped to the path of the field identifying their
+// elements. Harness doesn't keep the order of these lists in the yaml it returns.
+var unorderedLists = map[string][]string{
+	"variables":   {"name"},
+	"configFiles": {"configFile", "identifier"},
+	"sidecars":    {"sidecar", "identifier"},
+}
+
+// NormalizeYaml parses the yaml of a Harness entity into a canonical form where:
+//   - the orgIdentifier and projectIdentifier fields of the entity are removed,
+//   - empty tags, empty descriptions and null fields are removed,
+//   - the lists whose order is not significant, like variables, are sorted by the identifier of their elements,
+//   - scalars are compared by their string value, so that 1 and "1" are equal, and runtime inputs like <+input>
+//     are trimmed.
+func NormalizeYaml(s string) (interface{}, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return normalizeYamlValue(v, 0), nil
+}
+
+func normalizeYamlValue(v interface{}, depth int) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			// The scope fields are set on the root of the yaml or on the entity, e.g. pipeline.orgIdentifier
+			if depth <= 1 && entityScopeFields[key] {
+				continue
+			}
+			value = normalizeYamlValue(value, depth+1)
+			if isDefaultYamlValue(key, value) {
+				continue
+			}
+			if path, ok := unorderedLists[key]; ok {
+				value = sortYamlList(value, path)
+			}
+			m[key] = value
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalizeYamlValue(value, depth+1)
+		}
+		return l
+	case string:
+		if trimmed := strings.TrimSpace(v); strings.HasPrefix(trimmed, "<+") {
+			return trimmed
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isDefaultYamlValue(key string, value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return key == "tags" && len(value) == 0
+	case string:
+		return key == "description" && value == ""
+	}
+	return false
+}
+
+// sortYamlList sorts the elements of a list by the value at the given path. Lists with elements missing the path
+// are left untouched.
+func sortYamlList(v interface{}, path []string) interface{} {
+	l, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	keys := make([]string, len(l))
+	for i, element := range l {
+		key, ok := yamlValueAtPath(element, path)
+		if !ok {
+			return v
+		}
+		keys[i] = key
+	}
 
+	indexes := make([]int, len(l))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return keys[indexes[i]] < keys[indexes[j]] })
+
+	sorted := make([]interface{}, len(l))
+	for i, index := range indexes {
+		sorted[i] = l[index]
+	}
+	return sorted
+}
+
+func yamlValueAtPath(v interface{}, path []string) (string, bool) {
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		v = m[key]
+	}
+	s, ok := v.(string)
+	return s, ok
 }
@@ -55,5 +55,107 @@ field2: value2
 		`---
 field1: value1
 `, nil))
+}
 
+func TestYamlDiffSuppressFunction_harnessDefaults(t *testing.T) {
+	// Harness adds the scope, empty tags and descriptions to the yaml of the entities.
+	require.True(t, helpers.YamlDiffSuppressFunction("",
+		`pipeline:
+  name: test
+  identifier: test
+  stages: []
+`,
+		`pipeline:
+  name: test
+  identifier: test
+  orgIdentifier: org
+  projectIdentifier: project
+  description: ""
+  tags: {}
+  stages: []
+`, nil))
+
+	// The scope is only ignored on the entity, not on the references it contains.
+	require.False(t, helpers.YamlDiffSuppressFunction("",
+		`pipeline:
+  identifier: test
+  template:
+    templateRef: template
+`,
+		`pipeline:
+  identifier: test
+  template:
+    templateRef: template
+    orgIdentifier: org
+`, nil))
+
+	require.False(t, helpers.YamlDiffSuppressFunction("",
+		`service:
+  identifier: test
+  tags:
+    owner: team
+`,
+		`service:
+  identifier: test
+  tags: {}
+`, nil))
+}
+
+func TestYamlDiffSuppressFunction_unorderedLists(t *testing.T) {
+	require.True(t, helpers.YamlDiffSuppressFunction("",
+		`pipeline:
+  variables:
+    - name: b
+      type: String
+      value: <+input>
+    - name: a
+      type: Number
+      value: 1
+`,
+		`pipeline:
+  variables:
+    - name: a
+      type: Number
+      value: "1"
+    - name: b
+      type: String
+      value: "<+input> "
+`, nil))
+
+	require.True(t, helpers.YamlDiffSuppressFunction("",
+		`service:
+  serviceDefinition:
+    spec:
+      configFiles:
+        - configFile:
+            identifier: b
+        - configFile:
+            identifier: a
+`,
+		`service:
+  serviceDefinition:
+    spec:
+      configFiles:
+        - configFile:
+            identifier: a
+        - configFile:
+            identifier: b
+`, nil))
+
+	// The order of the stages is significant.
+	require.False(t, helpers.YamlDiffSuppressFunction("",
+		`pipeline:
+  stages:
+    - stage:
+        identifier: a
+    - stage:
+        identifier: b
+`,
+		`pipeline:
+  stages:
+    - stage:
+        identifier: b
+    - stage:
+        identifier: a
+`, nil))
 }
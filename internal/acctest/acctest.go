@@ -15,8 +15,10 @@ import (
 	"github.com/harness/harness-go-sdk/harness/policymgmt"
 	"github.com/harness/harness-go-sdk/harness/utils"
 	openapi_client_nextgen "github.com/harness/harness-openapi-go-client/nextgen"
+	tf_helpers "github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/harness/terraform-provider-harness/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -47,6 +49,7 @@ func TestAccConfigureProvider() {
 }
 
 func TestAccPreCheck(t *testing.T) {
+	useCassette(t)
 	TestAccConfigureProvider()
 }
 
@@ -94,253 +97,201 @@ func TestAccGetApplication(resourceName string, state *terraform.State) (*graphq
 	return c.CDClient.ApplicationClient.GetApplicationById(id)
 }
 
-func PipelineResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+// ScopedResourceImportStateIdFunc returns the import id of a resource in the format parsed by helpers.ParseImportId:
+// [<org_id>/[<project_id>/]]<field>/.../<field>. The fields are attributes of the resource, "id" being its id.
+func ScopedResourceImportStateIdFunc(resourceName string, fields ...string) resource.ImportStateIdFunc {
+	return scopedResourceImportStateIdFunc(resourceName, "", fields...)
+}
+
+// GitResourceImportStateIdFunc returns the import id of a resource stored in git, suffixed with @<branch> when the
+// git_details of the resource contain a branch.
+func GitResourceImportStateIdFunc(resourceName string, fields ...string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		var pipelineId string
-		if len(primary.Attributes["pipeline_id"]) != 0 {
-			pipelineId = primary.Attributes["pipeline_id"]
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
 		}
-		if len(primary.Attributes["target_id"]) != 0 {
-			pipelineId = primary.Attributes["target_id"]
+		branch := primary.Attributes["git_details.0.branch_name"]
+		if branch == "" {
+			branch = primary.Attributes["git_details.0.branch"]
 		}
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, pipelineId, id), nil
+		return scopedResourceImportStateIdFunc(resourceName, branch, fields...)(s)
 	}
 }
 
-func EnvRelatedResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+func scopedResourceImportStateIdFunc(resourceName string, branch string, fields ...string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		var envId string
-		if len(primary.Attributes["env_id"]) != 0 {
-			envId = primary.Attributes["env_id"]
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
 		}
-		if orgId == "" {
-			return fmt.Sprintf("%s/%s", envId, id), nil
+		parts := make([]string, len(fields))
+		for i, field := range fields {
+			if field == "id" {
+				parts[i] = primary.ID
+			} else {
+				parts[i] = primary.Attributes[field]
+			}
 		}
-		if projId == "" {
-			return fmt.Sprintf("%s/%s/%s", orgId, envId, id), nil
-		}
-
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, envId, id), nil
+		return tf_helpers.FormatImportId(primary.Attributes["org_id"], primary.Attributes["project_id"], branch, parts...), nil
 	}
 }
 
-func DBInstanceResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		schema := primary.Attributes["schema"]
-
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, schema, id), nil
+func getPrimaryInstanceState(s *terraform.State, resourceName string) (*terraform.InstanceState, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok || rs.Primary == nil {
+		return nil, fmt.Errorf("resource %s not found in the state", resourceName)
 	}
+	return rs.Primary, nil
 }
 
-func OverridesV1ResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+func PipelineResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		var envId string
-		if len(primary.Attributes["env_id"]) != 0 {
-			envId = primary.Attributes["env_id"]
-		}
-		if orgId == "" {
-			return fmt.Sprintf("%s", envId), nil
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
 		}
-		if projId == "" {
-			return fmt.Sprintf("%s/%s", orgId, envId), nil
+		if len(primary.Attributes["target_id"]) != 0 {
+			return ScopedResourceImportStateIdFunc(resourceName, "target_id", "id")(s)
 		}
-
-		return fmt.Sprintf("%s/%s/%s", orgId, projId, envId), nil
+		return ScopedResourceImportStateIdFunc(resourceName, "pipeline_id", "id")(s)
 	}
 }
 
+func EnvRelatedResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return ScopedResourceImportStateIdFunc(resourceName, "env_id", "id")
+}
+
+func DBInstanceResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return ScopedResourceImportStateIdFunc(resourceName, "schema", "id")
+}
+
+func OverridesV1ResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return ScopedResourceImportStateIdFunc(resourceName, "env_id")
+}
+
 func RepoResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		return fmt.Sprintf("%s/%s/%s", orgId, projId, id), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "id")
 }
 
 func ProjectResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		return fmt.Sprintf("%s/%s/%s", orgId, projId, id), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "id")
 }
 
 func ProjectResourceImportStateIdGitFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		branch_name := primary.Attributes["git_details.0.branch_name"]
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, id, branch_name), nil
-	}
+	return GitResourceImportStateIdFunc(resourceName, "id")
 }
 
+// UserResourceImportStateIdFunc returns the import id of a user, in the format <email>[/<org_id>[/<project_id>]].
 func UserResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		email := primary.Attributes["email"]
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		return fmt.Sprintf("%s/%s/%s", email, orgId, projId), nil
-	}
+	return trailingScopeImportStateIdFunc(resourceName, "email")
 }
 
 func UserResourceImportStateIdFuncAccountLevel(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		email := primary.Attributes["email"]
-		return fmt.Sprintf("%s", email), nil
-	}
+	return trailingScopeImportStateIdFunc(resourceName, "email")
 }
 
 func UserResourceImportStateIdFuncOrgLevel(resourceName string) resource.ImportStateIdFunc {
+	return trailingScopeImportStateIdFunc(resourceName, "email")
+}
+
+func trailingScopeImportStateIdFunc(resourceName string, field string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		email := primary.Attributes["email"]
-		orgId := primary.Attributes["org_id"]
-		return fmt.Sprintf("%s/%s", email, orgId), nil
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
+		}
+		id := primary.Attributes[field]
+		if orgId := primary.Attributes["org_id"]; orgId != "" {
+			id += "/" + orgId
+			if projId := primary.Attributes["project_id"]; projId != "" {
+				id += "/" + projId
+			}
+		}
+		return id, nil
 	}
 }
 
 func AccountLevelResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		return fmt.Sprintf("%s", id), nil
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
+		}
+		return primary.ID, nil
 	}
 }
 
 func AccountFilterImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		type_ := primary.Attributes["type"]
-		return fmt.Sprintf("%s/%s", id, type_), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "id", "type")
 }
+
 func ProjectFilterImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		type_ := primary.Attributes["type"]
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, id, type_), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "id", "type")
 }
 
 func GitopsAgentProjectLevelResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		agentId := primary.Attributes["agent_id"]
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, agentId, id), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "agent_id", "id")
 }
 
 // Import of GitopsAppProjectMapping resource is always on project level
 // terraform import  harness_platform_gitops_app_project_mapping.example org_id/projec_id/scope_prefixed_agent_id/argo_proj_name
 func GitopsAppProjectMappingResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		agentId := primary.Attributes["agent_id"]
-		argoProjName := primary.Attributes["argo_project_name"]
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, agentId, argoProjName), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "agent_id", "argo_project_name")
 }
 
 func GitopsAgentOrgLevelResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		agentId := primary.Attributes["agent_id"]
-		return fmt.Sprintf("%s/%s/%s", orgId, agentId, id), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "agent_id", "id")
 }
 
 func GitopsProjectImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
+		}
 		agentId := primary.Attributes["agent_id"]
 		query_name := primary.Attributes["project.0.metadata.0.name"]
-		return fmt.Sprintf("%s/%s", agentId, query_name), nil
+		return tf_helpers.FormatImportId("", "", "", agentId, query_name), nil
 	}
 }
 
 func GitopsAgentAccountLevelResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		agentId := primary.Attributes["agent_id"]
-		return fmt.Sprintf("%s/%s", agentId, id), nil
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
+		}
+		return tf_helpers.FormatImportId("", "", "", primary.Attributes["agent_id"], primary.ID), nil
 	}
 }
 
 func RepoRuleProjectLevelResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		repoIdentifier := primary.Attributes["repo_identifier"]
-		return fmt.Sprintf("%s/%s/%s/%s", orgId, projId, repoIdentifier, id), nil
-	}
+	return ScopedResourceImportStateIdFunc(resourceName, "repo_identifier", "id")
 }
 
 func OrgResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		return fmt.Sprintf("%s/%s", orgId, id), nil
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
+		}
+		return tf_helpers.FormatImportId(primary.Attributes["org_id"], "", "", primary.ID), nil
 	}
 }
 
 func OrgFilterImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		id := primary.ID
-		orgId := primary.Attributes["org_id"]
-		type_ := primary.Attributes["type"]
-		return fmt.Sprintf("%s/%s/%s", orgId, id, type_), nil
+		primary, err := getPrimaryInstanceState(s, resourceName)
+		if err != nil {
+			return "", err
+		}
+		return tf_helpers.FormatImportId(primary.Attributes["org_id"], "", "", primary.ID, primary.Attributes["type"]), nil
 	}
 }
 
 func GitopsWebhookImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-	return func(s *terraform.State) (string, error) {
-		primary := s.RootModule().Resources[resourceName].Primary
-		webhook_identifier := primary.Attributes["identifier"]
-		orgId := primary.Attributes["org_id"]
-		projId := primary.Attributes["project_id"]
-		return fmt.Sprintf("%s/%s/%s", webhook_identifier, orgId, projId), nil
-	}
+	return trailingScopeImportStateIdFunc(resourceName, "identifier")
 }
 
 // providerFactories are used to instantiate a provider during acceptance testing.
@@ -352,6 +303,18 @@ var ProviderFactories = map[string]func() (*schema.Provider, error){
 	},
 }
 
+// ProtoV5ProviderFactories instantiate the provider combining the SDK and framework providers, which is required to
+// test the resources implemented with the framework.
+var ProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"harness": func() (tfprotov5.ProviderServer, error) {
+		server, err := provider.ProviderServer(context.Background(), "dev")
+		if err != nil {
+			return nil, err
+		}
+		return server(), nil
+	},
+}
+
 func TestAccResourceAwsCloudProvider(name string) string {
 	return fmt.Sprintf(`
 	data "harness_secret_manager" "default" {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/harness/harness-go-sdk/harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
@@ -61,3 +62,58 @@ func TestProvider_configure_url_env(t *testing.T) {
 	c := p.Meta().(*internal.Session)
 	require.Equal(t, expectedEndpoint, c.Endpoint)
 }
+
+func TestProvider_configure_retries(t *testing.T) {
+
+	// Setup provider
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"endpoint":       "http://localhost:8200",
+		"account_id":     "test",
+		"api_key":        "test",
+		"max_retries":    3,
+		"retry_wait_min": 2,
+		"retry_wait_max": 5,
+	})
+	p := provider.Provider("dev")()
+	diags := p.Configure(context.TODO(), rc)
+
+	// Verify
+	require.False(t, diags.HasError())
+	c := p.Meta().(*internal.Session)
+	require.Equal(t, 3, c.CDClient.Configuration.HTTPClient.RetryMax)
+	require.Equal(t, 2*time.Second, c.CDClient.Configuration.HTTPClient.RetryWaitMin)
+	require.Equal(t, 5*time.Second, c.CDClient.Configuration.HTTPClient.RetryWaitMax)
+}
+
+func testImportState(resourceName string, id string, attributes map[string]string) *terraform.State {
+	s := terraform.NewState()
+	s.RootModule().Resources[resourceName] = &terraform.ResourceState{
+		Type:    "harness_platform_pipeline",
+		Primary: &terraform.InstanceState{ID: id, Attributes: attributes},
+	}
+	return s
+}
+
+func TestImportStateIdFuncs(t *testing.T) {
+	s := testImportState("harness_platform_pipeline.test", "pipeline", map[string]string{
+		"org_id":                    "org",
+		"project_id":                "project",
+		"git_details.0.branch_name": "main",
+	})
+
+	id, err := ProjectResourceImportStateIdFunc("harness_platform_pipeline.test")(s)
+	require.NoError(t, err)
+	require.Equal(t, "org/project/pipeline", id)
+
+	id, err = ProjectResourceImportStateIdGitFunc("harness_platform_pipeline.test")(s)
+	require.NoError(t, err)
+	require.Equal(t, "org/project/pipeline@main", id)
+
+	s = testImportState("harness_platform_connector_vault.test", "vault", map[string]string{"org_id": "org", "project_id": ""})
+	id, err = ProjectResourceImportStateIdFunc("harness_platform_connector_vault.test")(s)
+	require.NoError(t, err)
+	require.Equal(t, "org/vault", id)
+
+	_, err = ProjectResourceImportStateIdFunc("harness_platform_connector_vault.missing")(s)
+	require.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// execution is a pipeline execution triggered manually, which has a single stage made of a single step.
+type execution struct {
+	id                 string
+	org                string
+	project            string
+	pipeline           string
+	branch             string
+	inputSetReferences []string
+	runtimeInputs      map[string]interface{}
+	// statuses are the statuses reported by the next reads of the execution, the last one being kept
+	statuses []string
+	startTs  int64
+}
+
+// SetExecutionStatuses sets the statuses reported by the pipeline executions started from now on, one for each
+// time an execution is read, the last one being kept. By default, the executions succeed immediately.
+func (s *Server) SetExecutionStatuses(statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executionStatuses = statuses
+}
+
+// ExecutionInputs returns the input set references and the runtime inputs a pipeline execution was started with.
+func (s *Server) ExecutionInputs(id string) ([]string, map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.executions[id]
+	if !ok {
+		return nil, nil
+	}
+	return e.inputSetReferences, e.runtimeInputs
+}
+
+// handleExecute implements the /pipeline/api/pipeline/execute/{pipeline} and
+// /pipeline/api/pipeline/execute/{pipeline}/inputSetList endpoints starting the executions of the pipelines.
+func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
+	path := pathIdentifier(r, "/pipeline/api/pipeline/execute")
+	pipeline, withInputSets := strings.CutSuffix(path, "/inputSetList")
+	if r.Method != http.MethodPost || pipeline == "" || strings.Contains(pipeline, "/") {
+		writeJSON(w, http.StatusNotFound, failure("RESOURCE_NOT_FOUND_EXCEPTION", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+		return
+	}
+
+	query := r.URL.Query()
+	e := &execution{
+		org:      query.Get("orgIdentifier"),
+		project:  query.Get("projectIdentifier"),
+		pipeline: pipeline,
+		branch:   query.Get("branch"),
+		startTs:  time.Now().UnixMilli(),
+	}
+	if r.ContentLength != 0 {
+		body, err := readBody(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", err.Error()))
+			return
+		}
+		if withInputSets {
+			for _, ref := range body["inputSetReferences"].([]interface{}) {
+				e.inputSetReferences = append(e.inputSetReferences, ref.(string))
+			}
+		} else {
+			e.runtimeInputs = body
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entities[newEntityKey(kindPipeline, e.org, e.project, pipeline)]; !ok {
+		writeJSON(w, http.StatusBadRequest, notFound(kindPipeline, pipeline))
+		return
+	}
+	e.id = fmt.Sprintf("execution%d", len(s.executions)+1)
+	e.statuses = s.executionStatuses
+	if len(e.statuses) == 0 {
+		e.statuses = []string{"Success"}
+	}
+	s.executions[e.id] = e
+
+	writeJSON(w, http.StatusOK, success(map[string]interface{}{
+		"planExecution": map[string]interface{}{
+			"uuid":    e.id,
+			"status":  "RUNNING",
+			"startTs": e.startTs,
+		},
+	}))
+}
+
+// handleExecutionDetail implements the /pipeline/api/pipelines/execution/v2/{execution} endpoint, which returns
+// the execution graph of the step of the execution when the stageNodeId parameter is set.
+func (s *Server) handleExecutionDetail(w http.ResponseWriter, r *http.Request) {
+	id := pathIdentifier(r, "/pipeline/api/pipelines/execution/v2")
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.executions[id]
+	if r.Method != http.MethodGet || !ok || e.org != query.Get("orgIdentifier") || e.project != query.Get("projectIdentifier") {
+		writeJSON(w, http.StatusBadRequest, failure("RESOURCE_NOT_FOUND_EXCEPTION", fmt.Sprintf("Plan Execution with id [%s] is not present or deleted", id)))
+		return
+	}
+
+	status := e.statuses[0]
+	if len(e.statuses) > 1 {
+		e.statuses = e.statuses[1:]
+	}
+
+	detail := map[string]interface{}{"pipelineExecutionSummary": e.summary(status)}
+	if query.Get("stageNodeId") == "stage_node" {
+		detail["executionGraph"] = map[string]interface{}{
+			"rootNodeId": "step_node",
+			"nodeMap": map[string]interface{}{
+				"step_node": map[string]interface{}{
+					"uuid":       "step_node",
+					"identifier": "step",
+					"name":       "Step",
+					"status":     status,
+					"outcomes": map[string]interface{}{
+						"output": map[string]interface{}{
+							"outputVariables": map[string]interface{}{"status": status},
+						},
+					},
+				},
+			},
+		}
+	}
+	writeJSON(w, http.StatusOK, success(detail))
+}
+
+// handleExecutionList implements the /pipeline/api/pipelines/execution/summary endpoint listing the executions of
+// a pipeline, the most recent first, filtered by the status, time range and trigger types of the filter properties.
+func (s *Server) handleExecutionList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusNotFound, failure("RESOURCE_NOT_FOUND_EXCEPTION", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+		return
+	}
+	query := r.URL.Query()
+	filter := map[string]interface{}{}
+	if r.ContentLength != 0 {
+		body, err := readBody(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", err.Error()))
+			return
+		}
+		filter = body
+	}
+	statuses := stringSet(filter["status"])
+	triggerTypes := stringSet(filter["triggerTypes"])
+	timeRange := object(filter["timeRange"])
+	if timeRange != nil && (timeRange["startTime"] == nil || timeRange["endTime"] == nil) {
+		writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", "The time range requires both startTime and endTime"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var content []interface{}
+	for i := len(s.executions); i > 0; i-- {
+		e := s.executions[fmt.Sprintf("execution%d", i)]
+		status := e.statuses[0]
+		switch {
+		case e.org != query.Get("orgIdentifier") || e.project != query.Get("projectIdentifier"):
+		case query.Has("pipelineIdentifier") && e.pipeline != query.Get("pipelineIdentifier"):
+		case query.Has("branch") && e.branch != query.Get("branch"):
+		case len(statuses) > 0 && !statuses[status]:
+		case len(triggerTypes) > 0 && !triggerTypes["MANUAL"]:
+		case timeRange != nil && !inTimeRange(e.startTs, timeRange):
+		default:
+			content = append(content, e.summary(status))
+		}
+	}
+
+	pageIndex := intValue(query.Get("page"), 0)
+	pageSize := intValue(query.Get("size"), 20)
+	page, totalPages := paginate(content, pageIndex, pageSize)
+	writeJSON(w, http.StatusOK, success(map[string]interface{}{
+		"content":       page,
+		"number":        pageIndex,
+		"size":          pageSize,
+		"totalElements": len(content),
+		"totalPages":    totalPages,
+		"first":         pageIndex == 0,
+		"last":          pageIndex >= totalPages-1,
+	}))
+}
+
+// summary returns the summary of the execution with the given status. The executions are triggered manually.
+func (e *execution) summary(status string) map[string]interface{} {
+	summary := map[string]interface{}{
+		"pipelineIdentifier": e.pipeline,
+		"planExecutionId":    e.id,
+		"status":             status,
+		"startTs":            e.startTs,
+		"startingNodeId":     "stage_node",
+		"executionTriggerInfo": map[string]interface{}{
+			"triggerType": "MANUAL",
+			"triggeredBy": map[string]interface{}{
+				"identifier": "fake_user",
+				"extraInfo":  map[string]interface{}{"email": "fake_user@harness.io"},
+			},
+		},
+		"layoutNodeMap": map[string]interface{}{
+			"stage_node": map[string]interface{}{
+				"nodeType":       "Custom",
+				"nodeGroup":      "STAGE",
+				"nodeIdentifier": "stage",
+				"name":           "Stage",
+				"nodeUuid":       "stage_node",
+				"status":         status,
+				"edgeLayoutList": map[string]interface{}{"currentNodeChildren": []string{}, "nextIds": []string{}},
+			},
+		},
+	}
+	if e.branch != "" {
+		summary["gitDetails"] = map[string]interface{}{"branch": e.branch}
+	}
+	if status != "Running" {
+		summary["endTs"] = e.startTs + 1000
+	}
+	if status == "Failed" {
+		summary["executionErrorInfo"] = map[string]interface{}{"message": "Step failed"}
+	}
+	return summary
+}
+
+func stringSet(v interface{}) map[string]bool {
+	set := map[string]bool{}
+	values, _ := v.([]interface{})
+	for _, value := range values {
+		set[fmt.Sprint(value)] = true
+	}
+	return set
+}
+
+// inTimeRange returns whether a time is in a time range.
+func inTimeRange(ts int64, timeRange map[string]interface{}) bool {
+	start, _ := timeRange["startTime"].(float64)
+	end, _ := timeRange["endTime"].(float64)
+	return float64(ts) >= start && float64(ts) <= end
+}
@@ -0,0 +1,241 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// handleInputSets implements the /v1/orgs/{org}/projects/{project}/input-sets endpoints of the input sets and of
+// the overlay input sets of a pipeline, which are told apart by their YAML.
+func (s *Server) handleInputSets(w http.ResponseWriter, r *http.Request, org string, project string, parts []string) {
+	if len(parts) > 1 {
+		writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+		return
+	}
+	id := ""
+	if len(parts) == 1 {
+		id = parts[0]
+	}
+	pipeline := r.URL.Query().Get("pipeline")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entities[newEntityKey(kindPipeline, org, project, pipeline)]; !ok {
+		writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", fmt.Sprintf("Pipeline [%s] not found", pipeline)))
+		return
+	}
+	key := newEntityKey(kindInputSet, org, project, pipeline+"/"+id)
+
+	switch {
+	case r.Method == http.MethodGet && id != "":
+		stored, ok := s.entities[key]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", fmt.Sprintf("InputSet with the given ID: %s does not exist or has been deleted", id)))
+			return
+		}
+		writeJSON(w, http.StatusOK, inputSetResponse(stored, org, project))
+
+	case r.Method == http.MethodGet && id == "":
+		var inputSets []interface{}
+		for _, stored := range s.list(kindInputSet, org, project) {
+			if stringValue(stored.data, "pipeline") == pipeline {
+				inputSets = append(inputSets, inputSetResponse(stored, org, project))
+			}
+		}
+		query := r.URL.Query()
+		page, _ := paginate(inputSets, intValue(query.Get("page"), 0), intValue(query.Get("limit"), 30))
+		writeJSON(w, http.StatusOK, page)
+
+	case r.Method == http.MethodPost && id == "", r.Method == http.MethodPut && id != "":
+		body, err := readBody(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, v1Error("INVALID_REQUEST", err.Error()))
+			return
+		}
+		inputSetId := stringValue(body, "identifier")
+		if id != "" && inputSetId != id {
+			writeJSON(w, http.StatusBadRequest, v1Error("INVALID_REQUEST", "identifier in the path and in the body do not match"))
+			return
+		}
+		if err := s.validateInputSetYaml(org, project, pipeline, stringValue(body, "input_set_yaml")); err != nil {
+			writeJSON(w, http.StatusBadRequest, v1Error("INVALID_REQUEST", err.Error()))
+			return
+		}
+		if stored, ok := s.entities[key]; ok && id != "" {
+			// The git details are only set when the input set is created.
+			body["git_details"] = stored.data["git_details"]
+		}
+		body["pipeline"] = pipeline
+		stored, status, failed := s.save(kindInputSet, org, project, pipeline+"/"+inputSetId, body, r.Method == http.MethodPost)
+		if failed != nil {
+			writeJSON(w, status, v1Error(stringValue(failed, "code"), stringValue(failed, "message")))
+			return
+		}
+		writeJSON(w, http.StatusOK, inputSetResponse(stored, org, project))
+
+	case r.Method == http.MethodDelete && id != "":
+		if _, ok := s.entities[key]; !ok {
+			writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", fmt.Sprintf("InputSet with the given ID: %s does not exist or has been deleted", id)))
+			return
+		}
+		delete(s.entities, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+	}
+}
+
+// validateInputSetYaml checks that the YAML describes an input set, or an overlay input set whose references are
+// input sets of the same pipeline.
+func (s *Server) validateInputSetYaml(org string, project string, pipeline string, inputSetYaml string) error {
+	doc, err := parseInputSetYaml(inputSetYaml)
+	if err != nil {
+		return err
+	}
+	switch {
+	case doc.InputSet != nil:
+		return nil
+	case doc.OverlayInputSet == nil:
+		return fmt.Errorf("input_set_yaml must describe an inputSet or an overlayInputSet")
+	case len(doc.OverlayInputSet.InputSetReferences) == 0:
+		return fmt.Errorf("Input Set References can't be empty")
+	}
+	for _, ref := range doc.OverlayInputSet.InputSetReferences {
+		stored, ok := s.entities[newEntityKey(kindInputSet, org, project, pipeline+"/"+ref)]
+		if !ok {
+			return fmt.Errorf("Reference %s does not exist", ref)
+		}
+		if stored, _ := parseInputSetYaml(stringValue(stored.data, "input_set_yaml")); stored.OverlayInputSet != nil {
+			return fmt.Errorf("Reference %s is an overlay input set", ref)
+		}
+	}
+	return nil
+}
+
+type inputSetYaml struct {
+	InputSet        map[string]interface{} `yaml:"inputSet"`
+	OverlayInputSet *struct {
+		InputSetReferences []string `yaml:"inputSetReferences"`
+	} `yaml:"overlayInputSet"`
+}
+
+func parseInputSetYaml(s string) (inputSetYaml, error) {
+	var doc inputSetYaml
+	err := yaml.Unmarshal([]byte(s), &doc)
+	return doc, err
+}
+
+func inputSetResponse(stored *entity, org string, project string) map[string]interface{} {
+	response := map[string]interface{}{
+		"identifier":     stringValue(stored.data, "identifier"),
+		"name":           stringValue(stored.data, "name"),
+		"description":    stringValue(stored.data, "description"),
+		"tags":           stored.data["tags"],
+		"input_set_yaml": stringValue(stored.data, "input_set_yaml"),
+		"org":            org,
+		"project":        project,
+		"created":        stored.createdAt,
+		"updated":        stored.updatedAt,
+	}
+	if git := object(stored.data["git_details"]); stringValue(git, "store_type") == "REMOTE" {
+		response["git_details"] = map[string]interface{}{
+			"branch_name": stringValue(git, "branch_name"),
+			"file_path":   stringValue(git, "file_path"),
+			"repo_name":   stringValue(git, "repo_name"),
+			"commit_id":   "fake_commit",
+			"object_id":   "fake_object",
+		}
+	}
+	return response
+}
+
+// handleInputSetTemplate implements the /pipeline/api/inputSets/template endpoint returning the runtime input
+// template of a pipeline.
+func (s *Server) handleInputSetTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusNotFound, failure("RESOURCE_NOT_FOUND_EXCEPTION", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+		return
+	}
+	query := r.URL.Query()
+	id := query.Get("pipelineIdentifier")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.entities[newEntityKey(kindPipeline, query.Get("orgIdentifier"), query.Get("projectIdentifier"), id)]
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, notFound(kindPipeline, id))
+		return
+	}
+
+	template, err := runtimeInputTemplate(stringValue(stored.data, "pipeline_yaml"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, success(map[string]interface{}{
+		"inputSetTemplateYaml": template,
+		"modules":              []string{"cd"},
+	}))
+}
+
+// runtimeInputTemplate returns the runtime input template of a pipeline yaml, made of its runtime inputs and of the
+// identifier, name and type of the objects containing them. It is empty when the pipeline has no runtime inputs.
+func runtimeInputTemplate(pipelineYaml string) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(pipelineYaml), &doc); err != nil {
+		return "", err
+	}
+	if !pruneRuntimeInputs(&doc) {
+		return "", nil
+	}
+	template, err := yaml.Marshal(&doc)
+	return string(template), err
+}
+
+// pruneRuntimeInputs removes the nodes without runtime inputs and returns whether the node has any.
+func pruneRuntimeInputs(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return strings.HasPrefix(node.Value, "<+input>")
+
+	case yaml.MappingNode:
+		var content []*yaml.Node
+		found := false
+		for i := 0; i < len(node.Content); i += 2 {
+			if pruneRuntimeInputs(node.Content[i+1]) {
+				content = append(content, node.Content[i], node.Content[i+1])
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+		// The identifying keys are kept as they come, before the runtime inputs.
+		var identifiers []*yaml.Node
+		for i := 0; i < len(node.Content); i += 2 {
+			switch node.Content[i].Value {
+			case "identifier", "name", "type":
+				if node.Content[i+1].Kind == yaml.ScalarNode && !strings.HasPrefix(node.Content[i+1].Value, "<+input>") {
+					identifiers = append(identifiers, node.Content[i], node.Content[i+1])
+				}
+			}
+		}
+		node.Content = append(identifiers, content...)
+		return true
+
+	default:
+		var content []*yaml.Node
+		for _, child := range node.Content {
+			if pruneRuntimeInputs(child) {
+				content = append(content, child)
+			}
+		}
+		node.Content = content
+		return len(content) > 0
+	}
+}
@@ -0,0 +1,768 @@
+// Package fakeserver provides an in-memory fake of the core Harness NextGen API endpoints so that the CRUD logic
+// of the resources can be tested without a Harness account.
+//
+// The fake implements organizations, projects, connectors, secrets, services, environments, pipelines, input sets and
+// tokens, including their list endpoints, as well as the executions, the runtime input templates and the YAML
+// validation of the pipelines. It only validates what the resources rely on: the scope of the entities, duplicated
+// identifiers and missing entities.
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/harness/terraform-provider-harness/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// AccountId is the account of the fake server.
+	AccountId = "fake_account"
+	// PlatformApiKey is a platform_api_key accepted by the fake server.
+	PlatformApiKey = "pat." + AccountId + ".fake.token"
+	// DefaultOrgId is the organization that exists in every account.
+	DefaultOrgId = "default"
+)
+
+type kind string
+
+const (
+	kindOrganization kind = "organization"
+	kindProject      kind = "project"
+	kindConnector    kind = "connector"
+	kindSecret       kind = "secret"
+	kindService      kind = "service"
+	kindEnvironment  kind = "environment"
+	kindPipeline     kind = "pipeline"
+	kindInputSet     kind = "input_set"
+	kindToken        kind = "token"
+)
+
+type entityKey struct {
+	kind       kind
+	org        string
+	project    string
+	identifier string
+}
+
+type entity struct {
+	data      map[string]interface{}
+	createdAt int64
+	updatedAt int64
+}
+
+// Server is a fake Harness API server keeping its state in memory.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	entities map[entityKey]*entity
+	requests []string
+
+	executions        map[string]*execution
+	executionStatuses []string
+
+	// session is the session used by Create.
+	session *internal.Session
+}
+
+// New starts a fake server which is closed when the test completes.
+func New(t *testing.T) *Server {
+	s := &Server{entities: map[entityKey]*entity{}, executions: map[string]*execution{}}
+	s.entities[entityKey{kind: kindOrganization, identifier: DefaultOrgId}] = &entity{
+		data: map[string]interface{}{"identifier": DefaultOrgId, "name": "Default Organization"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ng/api/accounts/", s.handleAccount)
+	mux.HandleFunc("/ng/api/organizations", s.handleOrganizations)
+	mux.HandleFunc("/ng/api/organizations/", s.handleOrganizations)
+	mux.HandleFunc("/ng/api/projects", s.handleProjects)
+	mux.HandleFunc("/ng/api/projects/", s.handleProjects)
+	mux.HandleFunc("/ng/api/connectors", s.handleConnectors)
+	mux.HandleFunc("/ng/api/connectors/", s.handleConnectors)
+	mux.HandleFunc("/ng/api/v2/secrets", s.handleSecrets)
+	mux.HandleFunc("/ng/api/v2/secrets/", s.handleSecrets)
+	mux.HandleFunc("/ng/api/servicesV2", s.handleServices)
+	mux.HandleFunc("/ng/api/servicesV2/", s.handleServices)
+	mux.HandleFunc("/ng/api/environmentsV2", s.handleEnvironments)
+	mux.HandleFunc("/ng/api/environmentsV2/", s.handleEnvironments)
+	mux.HandleFunc("/ng/api/token", s.handleTokens)
+	mux.HandleFunc("/ng/api/token/", s.handleTokens)
+	mux.HandleFunc("/v1/orgs/", s.handlePipelines)
+	mux.HandleFunc("/pipeline/api/pipeline/execute/", s.handleExecute)
+	mux.HandleFunc("/pipeline/api/pipelines/execution/v2/", s.handleExecutionDetail)
+	mux.HandleFunc("/pipeline/api/pipelines/execution/summary", s.handleExecutionList)
+	mux.HandleFunc("/pipeline/api/inputSets/template", s.handleInputSetTemplate)
+	mux.HandleFunc("/pipeline/api/pipelines/validate-yaml-with-schema", s.handleValidatePipelineYaml)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Session returns a session whose clients send their requests to the fake server.
+func (s *Server) Session(t *testing.T) *internal.Session {
+	p := provider.Provider("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"endpoint":         s.URL,
+		"account_id":       AccountId,
+		"api_key":          "fake",
+		"platform_api_key": PlatformApiKey,
+		"max_retries":      0,
+	}))
+	if diags.HasError() {
+		t.Fatalf("error configuring the provider with the fake server: %v", diags)
+	}
+	return p.Meta().(*internal.Session)
+}
+
+// Create creates an entity with the CreateContext of a resource and the given configuration, failing the test if
+// it can't be created, and returns the data of the created resource.
+func (s *Server) Create(t *testing.T, r *schema.Resource, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	if s.session == nil {
+		s.session = s.Session(t)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(context.Background(), d, s.session); diags.HasError() {
+		t.Fatalf("error creating the entity %v: %v", raw["identifier"], diags)
+	}
+	return d
+}
+
+// SeedProject creates an organization and a project in it, both named after their identifier.
+func (s *Server) SeedProject(t *testing.T, org string, project string) {
+	t.Helper()
+	resources := provider.Provider("dev")().ResourcesMap
+	s.Create(t, resources["harness_platform_organization"], map[string]interface{}{"identifier": org, "name": org})
+	s.Create(t, resources["harness_platform_project"], map[string]interface{}{"identifier": project, "name": project, "org_id": org})
+}
+
+// ProviderServer returns the protocol server of the provider configured with the fake server, to test what is
+// implemented with terraform-plugin-framework such as the ephemeral resources.
+func (s *Server) ProviderServer(t *testing.T) tfprotov5.ProviderServer {
+	ctx := context.Background()
+	factory, err := provider.ProviderServer(ctx, "dev")
+	if err != nil {
+		t.Fatalf("error creating the provider server: %v", err)
+	}
+	server := factory()
+
+	config := newDynamicValue(t, getProviderSchema(t, server).Provider, map[string]tftypes.Value{
+		"endpoint":         tftypes.NewValue(tftypes.String, s.URL),
+		"account_id":       tftypes.NewValue(tftypes.String, AccountId),
+		"api_key":          tftypes.NewValue(tftypes.String, "fake"),
+		"platform_api_key": tftypes.NewValue(tftypes.String, PlatformApiKey),
+		"max_retries":      tftypes.NewValue(tftypes.Number, 0),
+	})
+	resp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: config})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("error configuring the provider server with the fake server: %v %v", err, diagnosticsString(resp.Diagnostics))
+	}
+	return server
+}
+
+// OpenEphemeralResource opens an ephemeral resource of the provider server with the given string attributes, and
+// returns the attributes of its result and the response to close it with.
+func OpenEphemeralResource(t *testing.T, server tfprotov5.ProviderServer, typeName string, attributes map[string]string) (map[string]tftypes.Value, *tfprotov5.OpenEphemeralResourceResponse) {
+	ctx := context.Background()
+	s, ok := getProviderSchema(t, server).EphemeralResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("the ephemeral resource %s does not exist", typeName)
+	}
+
+	values := map[string]tftypes.Value{}
+	for name, value := range attributes {
+		values[name] = tftypes.NewValue(tftypes.String, value)
+	}
+	resp, err := server.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config:   newDynamicValue(t, s, values),
+	})
+	if err != nil {
+		t.Fatalf("error opening %s: %v", typeName, err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		return nil, resp
+	}
+
+	result, err := resp.Result.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatalf("error reading the result of %s: %v", typeName, err)
+	}
+	resultValues := map[string]tftypes.Value{}
+	if err := result.As(&resultValues); err != nil {
+		t.Fatalf("error reading the result of %s: %v", typeName, err)
+	}
+	return resultValues, resp
+}
+
+func getProviderSchema(t *testing.T, server tfprotov5.ProviderServer) *tfprotov5.GetProviderSchemaResponse {
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("error getting the provider schema: %v %v", err, diagnosticsString(resp.Diagnostics))
+	}
+	return resp
+}
+
+// newDynamicValue returns a value of the schema with the given attributes, the other attributes being null.
+func newDynamicValue(t *testing.T, s *tfprotov5.Schema, attributes map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	objectType := s.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	v, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("error creating a value: %v", err)
+	}
+	return &v
+}
+
+func diagnosticsString(diags []*tfprotov5.Diagnostic) string {
+	var lines []string
+	for _, d := range diags {
+		lines = append(lines, d.Summary+": "+d.Detail)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Requests returns the method and path of the requests received by the server, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Exists reports whether the entity of the given kind exists, kind being one of organization, project, connector,
+// secret, service, environment, pipeline, input_set or token. The identifier of an input set is prefixed with the
+// identifier of its pipeline, as in pipeline/input_set.
+func (s *Server) Exists(k string, org string, project string, identifier string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.entities[newEntityKey(kind(k), org, project, identifier)]
+	return ok
+}
+
+func newEntityKey(k kind, org string, project string, identifier string) entityKey {
+	switch k {
+	case kindOrganization:
+		return entityKey{kind: k, identifier: identifier}
+	case kindProject:
+		return entityKey{kind: k, org: org, identifier: identifier}
+	}
+	return entityKey{kind: k, org: org, project: project, identifier: identifier}
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		if r.Header.Get("X-Api-Key") != PlatformApiKey {
+			writeJSON(w, http.StatusUnauthorized, failure("INVALID_TOKEN", "Token is not valid."))
+			return
+		}
+		if accountId := r.URL.Query().Get("accountIdentifier"); accountId != "" && accountId != AccountId {
+			writeJSON(w, http.StatusForbidden, failure("ACCESS_DENIED", "Not authorized to access account "+accountId))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/ng/api/accounts/")
+	if r.Method != http.MethodGet || id != AccountId {
+		writeJSON(w, http.StatusNotFound, failure("RESOURCE_NOT_FOUND_EXCEPTION", "Account "+id+" not found"))
+		return
+	}
+	writeJSON(w, http.StatusOK, success(map[string]interface{}{"identifier": AccountId, "name": "Fake Account"}))
+}
+
+func (s *Server) handleOrganizations(w http.ResponseWriter, r *http.Request) {
+	id := pathIdentifier(r, "/ng/api/organizations")
+	s.handleNG(w, r, ngEntity{
+		kind:     kindOrganization,
+		wrapper:  "organization",
+		id:       id,
+		body:     func(body map[string]interface{}) map[string]interface{} { return object(body["organization"]) },
+		response: s.wrappedResponse("organization", "lastModifiedAt"),
+	})
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	s.handleNG(w, r, ngEntity{
+		kind:     kindProject,
+		wrapper:  "project",
+		id:       pathIdentifier(r, "/ng/api/projects"),
+		body:     func(body map[string]interface{}) map[string]interface{} { return object(body["project"]) },
+		response: s.wrappedResponse("project", "lastModifiedAt"),
+	})
+}
+
+func (s *Server) handleConnectors(w http.ResponseWriter, r *http.Request) {
+	s.handleNG(w, r, ngEntity{
+		kind:      kindConnector,
+		wrapper:   "connector",
+		id:        pathIdentifier(r, "/ng/api/connectors"),
+		putNoPath: true,
+		body:      func(body map[string]interface{}) map[string]interface{} { return object(body["connector"]) },
+		response:  s.wrappedResponse("connector", "lastModifiedAt"),
+	})
+}
+
+func (s *Server) handleSecrets(w http.ResponseWriter, r *http.Request) {
+	s.handleNG(w, r, ngEntity{
+		kind:    kindSecret,
+		wrapper: "secret",
+		id:      pathIdentifier(r, "/ng/api/v2/secrets"),
+		body: func(body map[string]interface{}) map[string]interface{} {
+			secret := object(body["secret"])
+			// Inline secret values are never returned by the API
+			if spec := object(secret["spec"]); spec != nil && spec["valueType"] != "Reference" {
+				delete(spec, "value")
+			}
+			return secret
+		},
+		response: s.wrappedResponse("secret", "updatedAt"),
+	})
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	s.handleNG(w, r, ngEntity{
+		kind:      kindService,
+		wrapper:   "service",
+		id:        pathIdentifier(r, "/ng/api/servicesV2"),
+		putNoPath: true,
+		body: func(body map[string]interface{}) map[string]interface{} {
+			body["accountId"] = AccountId
+			return body
+		},
+		response: s.wrappedResponse("service", "lastModifiedAt"),
+	})
+}
+
+func (s *Server) handleEnvironments(w http.ResponseWriter, r *http.Request) {
+	s.handleNG(w, r, ngEntity{
+		kind:      kindEnvironment,
+		wrapper:   "environment",
+		id:        pathIdentifier(r, "/ng/api/environmentsV2"),
+		putNoPath: true,
+		body: func(body map[string]interface{}) map[string]interface{} {
+			body["accountId"] = AccountId
+			return body
+		},
+		response: s.wrappedResponse("environment", "lastModifiedAt"),
+	})
+}
+
+func (s *Server) handleTokens(w http.ResponseWriter, r *http.Request) {
+	s.handleNG(w, r, ngEntity{
+		kind:    kindToken,
+		wrapper: "token",
+		id:      pathIdentifier(r, "/ng/api/token"),
+		body:    func(body map[string]interface{}) map[string]interface{} { return body },
+		// Creating a token returns its value
+		response: func(e *entity) interface{} {
+			return "pat." + AccountId + "." + stringValue(e.data, "identifier") + ".fake"
+		},
+	})
+}
+
+// ngEntity describes how an entity of the /ng/api endpoints is read from the requests and written to the responses.
+type ngEntity struct {
+	kind    kind
+	wrapper string
+	// id is the identifier in the request path, if any
+	id string
+	// putNoPath is set when updates are sent without the identifier in the path
+	putNoPath bool
+	body      func(map[string]interface{}) map[string]interface{}
+	response  func(*entity) interface{}
+}
+
+func (s *Server) handleNG(w http.ResponseWriter, r *http.Request, e ngEntity) {
+	query := r.URL.Query()
+	org, project := query.Get("orgIdentifier"), query.Get("projectIdentifier")
+
+	switch {
+	case r.Method == http.MethodGet && e.id != "":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		stored, ok := s.entities[newEntityKey(e.kind, org, project, e.id)]
+		if !ok {
+			writeJSON(w, http.StatusBadRequest, notFound(e.kind, e.id))
+			return
+		}
+		writeJSON(w, http.StatusOK, success(e.response(stored)))
+
+	case r.Method == http.MethodPost && e.id == "", r.Method == http.MethodPut && (e.id != "" || e.putNoPath):
+		body, err := readBody(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", err.Error()))
+			return
+		}
+		data := e.body(body)
+		if data == nil {
+			writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", e.wrapper+" is required"))
+			return
+		}
+
+		id := stringValue(data, "identifier")
+		if e.id != "" && id != e.id {
+			writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", "identifier in the path and in the body do not match"))
+			return
+		}
+		if e.kind == kindOrganization {
+			org = ""
+		} else {
+			org = firstNonEmpty(stringValue(data, "orgIdentifier"), org)
+		}
+		if e.kind != kindOrganization && e.kind != kindProject {
+			project = firstNonEmpty(stringValue(data, "projectIdentifier"), project)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		stored, status, failed := s.save(e.kind, org, project, id, data, r.Method == http.MethodPost)
+		if failed != nil {
+			writeJSON(w, status, failed)
+			return
+		}
+		writeJSON(w, http.StatusOK, success(e.response(stored)))
+
+	case r.Method == http.MethodGet && e.id == "":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var content []interface{}
+		for _, stored := range s.list(e.kind, org, project) {
+			content = append(content, e.response(stored))
+		}
+		pageIndex := intValue(firstNonEmpty(query.Get("pageIndex"), query.Get("page")), 0)
+		pageSize := intValue(firstNonEmpty(query.Get("pageSize"), query.Get("size")), 100)
+		page, totalPages := paginate(content, pageIndex, pageSize)
+		writeJSON(w, http.StatusOK, success(map[string]interface{}{
+			"content":       page,
+			"pageIndex":     pageIndex,
+			"pageSize":      pageSize,
+			"pageItemCount": len(page),
+			"totalItems":    len(content),
+			"totalPages":    totalPages,
+		}))
+
+	case r.Method == http.MethodDelete && e.id != "":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		key := newEntityKey(e.kind, org, project, e.id)
+		if _, ok := s.entities[key]; !ok {
+			writeJSON(w, http.StatusBadRequest, notFound(e.kind, e.id))
+			return
+		}
+		delete(s.entities, key)
+		writeJSON(w, http.StatusOK, success(true))
+
+	default:
+		writeJSON(w, http.StatusNotFound, failure("RESOURCE_NOT_FOUND_EXCEPTION", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+	}
+}
+
+// save creates or updates an entity, the lock must be held by the caller.
+func (s *Server) save(k kind, org string, project string, id string, data map[string]interface{}, create bool) (*entity, int, map[string]interface{}) {
+	if id == "" {
+		return nil, http.StatusBadRequest, failure("INVALID_REQUEST", "identifier is required")
+	}
+	if project != "" && org == "" {
+		return nil, http.StatusBadRequest, failure("INVALID_REQUEST", "orgIdentifier is required when projectIdentifier is set")
+	}
+	if org != "" && k != kindOrganization {
+		if _, ok := s.entities[newEntityKey(kindOrganization, "", "", org)]; !ok {
+			return nil, http.StatusBadRequest, notFound(kindOrganization, org)
+		}
+	}
+	if project != "" && k != kindProject {
+		if _, ok := s.entities[newEntityKey(kindProject, org, "", project)]; !ok {
+			return nil, http.StatusBadRequest, notFound(kindProject, project)
+		}
+	}
+
+	key := newEntityKey(k, org, project, id)
+	stored, exists := s.entities[key]
+	if create && exists {
+		return nil, http.StatusBadRequest, failure("DUPLICATE_FIELD", fmt.Sprintf("A %s with identifier [%s] already exists", k, id))
+	}
+	if !create && !exists {
+		return nil, http.StatusBadRequest, notFound(k, id)
+	}
+
+	now := time.Now().UnixMilli()
+	if stored == nil {
+		stored = &entity{createdAt: now}
+		s.entities[key] = stored
+	}
+	stored.data = data
+	stored.updatedAt = now
+	return stored, 0, nil
+}
+
+// list returns the entities of a kind in a scope sorted by identifier, the lock must be held by the caller.
+// Organizations are listed regardless of the scope and projects are listed in every organization when org is empty.
+func (s *Server) list(k kind, org string, project string) []*entity {
+	var keys []entityKey
+	for key := range s.entities {
+		if key.kind != k {
+			continue
+		}
+		switch k {
+		case kindOrganization:
+		case kindProject:
+			if org != "" && key.org != org {
+				continue
+			}
+		default:
+			if key.org != org || key.project != project {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].identifier < keys[j].identifier })
+
+	entities := make([]*entity, len(keys))
+	for i, key := range keys {
+		entities[i] = s.entities[key]
+	}
+	return entities
+}
+
+func paginate(items []interface{}, pageIndex int, pageSize int) ([]interface{}, int) {
+	if pageSize <= 0 {
+		pageSize = len(items) + 1
+	}
+	totalPages := (len(items) + pageSize - 1) / pageSize
+	start := pageIndex * pageSize
+	if start >= len(items) {
+		return []interface{}{}, totalPages
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], totalPages
+}
+
+func (s *Server) wrappedResponse(wrapper string, updatedField string) func(*entity) interface{} {
+	return func(e *entity) interface{} {
+		return map[string]interface{}{
+			wrapper:      e.data,
+			"createdAt":  e.createdAt,
+			updatedField: e.updatedAt,
+		}
+	}
+}
+
+// handlePipelines implements the /v1/orgs/{org}/projects/{project}/pipelines endpoints used by the pipeline resource.
+func (s *Server) handlePipelines(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/orgs/"), "/"), "/")
+	if len(parts) >= 4 && parts[1] == "projects" && parts[3] == "input-sets" {
+		s.handleInputSets(w, r, parts[0], parts[2], parts[4:])
+		return
+	}
+	if len(parts) < 4 || parts[1] != "projects" || parts[3] != "pipelines" || len(parts) > 5 {
+		writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+		return
+	}
+	org, project, id := parts[0], parts[2], ""
+	if len(parts) == 5 {
+		id = parts[4]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && id != "":
+		stored, ok := s.entities[newEntityKey(kindPipeline, org, project, id)]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", fmt.Sprintf("Pipeline [%s] not found", id)))
+			return
+		}
+		response := map[string]interface{}{
+			"org":     org,
+			"project": project,
+			"valid":   true,
+			"created": stored.createdAt,
+			"updated": stored.updatedAt,
+		}
+		for k, v := range stored.data {
+			if k != "git_details" {
+				response[k] = v
+			}
+		}
+		writeJSON(w, http.StatusOK, response)
+
+	case r.Method == http.MethodGet && id == "":
+		var pipelines []interface{}
+		for _, stored := range s.list(kindPipeline, org, project) {
+			pipelines = append(pipelines, map[string]interface{}{
+				"identifier": stringValue(stored.data, "identifier"),
+				"name":       stringValue(stored.data, "name"),
+				"org":        org,
+				"project":    project,
+				"created":    stored.createdAt,
+				"updated":    stored.updatedAt,
+			})
+		}
+		query := r.URL.Query()
+		page, _ := paginate(pipelines, intValue(query.Get("page"), 0), intValue(query.Get("limit"), 30))
+		writeJSON(w, http.StatusOK, page)
+
+	case r.Method == http.MethodPost && id == "", r.Method == http.MethodPut && id != "":
+		body, err := readBody(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, v1Error("INVALID_REQUEST", err.Error()))
+			return
+		}
+		pipelineId := stringValue(body, "identifier")
+		if id != "" && pipelineId != id {
+			writeJSON(w, http.StatusBadRequest, v1Error("INVALID_REQUEST", "identifier in the path and in the body do not match"))
+			return
+		}
+		if stringValue(body, "pipeline_yaml") == "" {
+			writeJSON(w, http.StatusBadRequest, v1Error("INVALID_REQUEST", "pipeline_yaml is required"))
+			return
+		}
+		if _, status, failed := s.save(kindPipeline, org, project, pipelineId, body, r.Method == http.MethodPost); failed != nil {
+			writeJSON(w, status, v1Error(stringValue(failed, "code"), stringValue(failed, "message")))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"identifier": pipelineId})
+
+	case r.Method == http.MethodDelete && id != "":
+		key := newEntityKey(kindPipeline, org, project, id)
+		if _, ok := s.entities[key]; !ok {
+			writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", fmt.Sprintf("Pipeline [%s] not found", id)))
+			return
+		}
+		delete(s.entities, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeJSON(w, http.StatusNotFound, v1Error("RESOURCE_NOT_FOUND", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+	}
+}
+
+// handleValidatePipelineYaml implements the validation of the YAML of a pipeline, which only requires the YAML to
+// be parsed and to describe a pipeline with an identifier.
+func (s *Server) handleValidatePipelineYaml(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusNotFound, failure("RESOURCE_NOT_FOUND_EXCEPTION", "The fake server does not implement "+r.Method+" "+r.URL.Path))
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, failure("INVALID_REQUEST", err.Error()))
+		return
+	}
+	// The YAML is sent as a JSON string.
+	pipelineYaml := string(data)
+	json.Unmarshal(data, &pipelineYaml)
+
+	var doc struct {
+		Pipeline map[string]interface{} `yaml:"pipeline"`
+	}
+	if err := yaml.Unmarshal([]byte(pipelineYaml), &doc); err != nil {
+		writeJSON(w, http.StatusBadRequest, failure("INVALID_YAML_EXCEPTION", err.Error()))
+		return
+	}
+	if stringValue(doc.Pipeline, "identifier") == "" {
+		writeJSON(w, http.StatusBadRequest, failure("INVALID_YAML_EXCEPTION", "Pipeline identifier is missing"))
+		return
+	}
+	writeJSON(w, http.StatusOK, success(pipelineYaml))
+}
+
+func pathIdentifier(r *http.Request, prefix string) string {
+	return strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+}
+
+func readBody(r *http.Request) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	return body, nil
+}
+
+func object(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func stringValue(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func intValue(s string, defaultValue int) int {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v
+	}
+	return defaultValue
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func success(data interface{}) map[string]interface{} {
+	return map[string]interface{}{"status": "SUCCESS", "data": data}
+}
+
+func failure(code string, message string) map[string]interface{} {
+	return map[string]interface{}{"status": "ERROR", "code": code, "message": message}
+}
+
+func notFound(k kind, id string) map[string]interface{} {
+	return failure("RESOURCE_NOT_FOUND_EXCEPTION", fmt.Sprintf("The %s with identifier [%s] does not exist", k, id))
+}
+
+func v1Error(code string, message string) map[string]interface{} {
+	return map[string]interface{}{"code": code, "message": message}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,133 @@
+package fakeserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/service/cd_nextgen/environment"
+	"github.com/harness/terraform-provider-harness/internal/service/cd_nextgen/service"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/pipeline"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/connector"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/organization"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/project"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/secret"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+const pipelineYaml = `pipeline:
+  name: Test
+  identifier: test
+  projectIdentifier: project
+  orgIdentifier: org
+  stages: []
+`
+
+func TestServer_resourceLifecycle(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	create := func(r *schema.Resource, raw map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		diags := r.CreateContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		require.NotEmpty(t, d.Id())
+
+		diags = r.ReadContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		require.NotEmpty(t, d.Id())
+		return d
+	}
+
+	org := create(organization.ResourceOrganization(), map[string]interface{}{
+		"identifier": "org",
+		"name":       "Org",
+	})
+	create(project.ResourceProject(), map[string]interface{}{
+		"identifier": "project",
+		"name":       "Project",
+		"org_id":     "org",
+	})
+	require.True(t, server.Exists("project", "org", "", "project"))
+
+	secretText := create(secret.ResourceSecretText(), map[string]interface{}{
+		"identifier":                "secret",
+		"name":                      "Secret",
+		"org_id":                    "org",
+		"project_id":                "project",
+		"secret_manager_identifier": "harnessSecretManager",
+		"value_type":                "Inline",
+		"value":                     "value",
+	})
+	require.Equal(t, "value", secretText.Get("value"))
+
+	datadog := create(connector.ResourceConnectorDatadog(), map[string]interface{}{
+		"identifier":          "datadog",
+		"name":                "Datadog",
+		"org_id":              "org",
+		"project_id":          "project",
+		"url":                 "https://datadoghq.com",
+		"application_key_ref": "secret",
+		"api_key_ref":         "secret",
+	})
+	require.Equal(t, "https://datadoghq.com", datadog.Get("url"))
+
+	svc := create(service.ResourceService(), map[string]interface{}{
+		"identifier": "service",
+		"name":       "Service",
+		"org_id":     "org",
+		"project_id": "project",
+	})
+	require.Equal(t, "Service", svc.Get("name"))
+
+	create(environment.ResourceEnvironment(), map[string]interface{}{
+		"identifier": "environment",
+		"name":       "Environment",
+		"org_id":     "org",
+		"project_id": "project",
+		"type":       "PreProduction",
+	})
+
+	p := create(pipeline.ResourcePipeline(), map[string]interface{}{
+		"identifier": "test",
+		"name":       "Test",
+		"org_id":     "org",
+		"project_id": "project",
+		"yaml":       pipelineYaml,
+	})
+	require.Equal(t, "Test", p.Get("name"))
+	require.True(t, server.Exists("pipeline", "org", "project", "test"))
+
+	// Update
+	r := organization.ResourceOrganization()
+	require.NoError(t, org.Set("name", "Updated"))
+	diags := r.UpdateContext(ctx, org, session)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "Updated", org.Get("name"))
+
+	// Delete
+	r = pipeline.ResourcePipeline()
+	diags = r.DeleteContext(ctx, p, session)
+	require.False(t, diags.HasError(), diags)
+	require.False(t, server.Exists("pipeline", "org", "project", "test"))
+
+	// Entities deleted outside of Terraform are removed from the state
+	r = service.ResourceService()
+	diags = r.DeleteContext(ctx, svc, session)
+	require.False(t, diags.HasError(), diags)
+	diags = r.ReadContext(ctx, svc, session)
+	require.False(t, diags.HasError(), diags)
+	require.Empty(t, svc.Id())
+
+	// Projects can only be created in existing organizations
+	r = project.ResourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"identifier": "project",
+		"name":       "Project",
+		"org_id":     "missing",
+	})
+	diags = r.CreateContext(ctx, d, session)
+	require.True(t, diags.HasError())
+}
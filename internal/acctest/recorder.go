@@ -0,0 +1,550 @@
+package acctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal/provider"
+)
+
+const (
+	// RecorderModeEnvVar selects how the acceptance tests interact with the Harness API: `record` saves the
+	// interactions of every test to a cassette, `replay` serves the responses from the cassettes without any
+	// network access. When not set the tests run against the live API.
+	RecorderModeEnvVar = "HARNESS_ACC_RECORDER_MODE"
+	// CassetteDirEnvVar overrides the directory where the cassettes are stored, relative to the test package.
+	CassetteDirEnvVar = "HARNESS_ACC_CASSETTE_DIR"
+
+	RecorderModeRecord = "record"
+	RecorderModeReplay = "replay"
+
+	defaultCassetteDir = "testdata/cassettes"
+	redactedValue      = "REDACTED"
+)
+
+// redactedHeaders are never written to the cassettes.
+var redactedHeaders = []string{"X-Api-Key", "Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are the fields of the JSON and form bodies holding plaintext secrets, compared in lower case
+// without underscores. The fields referencing a secret, such as passwordRef, only hold its identifier and are kept
+// so that the requests can be matched when replaying. The value field holds the value of the secrets sent by the
+// provider, it is only redacted in the requests as Harness never returns it and many other entities use the field.
+var redactedFields = map[string]bool{
+	"token":         true,
+	"accesstoken":   true,
+	"refreshtoken":  true,
+	"idtoken":       true,
+	"subjecttoken":  true,
+	"password":      true,
+	"passphrase":    true,
+	"secret":        true,
+	"clientsecret":  true,
+	"secretkey":     true,
+	"privatekey":    true,
+	"apikey":        true,
+	"encryptedtext": true,
+}
+
+// apiKeyPattern matches the Harness personal access and service account tokens, such as the ones returned when
+// a token is created, wherever they appear in a body.
+var apiKeyPattern = regexp.MustCompile(`\b(pat|sat)\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+// Cassette holds the interactions with the Harness API recorded during a test.
+type Cassette struct {
+	AccountId    string        `json:"account_id"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string       `json:"method"`
+	URL    string       `json:"url"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   RecordedBody `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int          `json:"status_code"`
+	Header     http.Header  `json:"header,omitempty"`
+	Body       RecordedBody `json:"body,omitempty"`
+}
+
+// RecordedBody is stored as text when it is valid UTF-8 so that the cassettes can be reviewed, and as base64 otherwise.
+type RecordedBody []byte
+
+func (b RecordedBody) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *RecordedBody) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = RecordedBody(text)
+		return nil
+	}
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded["base64"])
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Recorder records the interactions with the Harness API to a cassette, or replays them from it.
+//
+// The acceptance tests generate random identifiers on every run, so when replaying, a request matches a recorded
+// one when they only differ by alphanumeric tokens of the same length. The recorded tokens are mapped to the ones
+// of the current run and substituted in the replayed responses.
+type Recorder struct {
+	mode string
+	dir  string
+
+	mu       sync.Mutex
+	name     string
+	cassette *Cassette
+	used     []bool
+	mapping  map[string]string
+	reverse  map[string]string
+}
+
+// NewRecorder returns a recorder storing its cassettes in dir.
+func NewRecorder(mode string, dir string) (*Recorder, error) {
+	if mode != RecorderModeRecord && mode != RecorderModeReplay {
+		return nil, fmt.Errorf("invalid %s %q, must be %q or %q", RecorderModeEnvVar, mode, RecorderModeRecord, RecorderModeReplay)
+	}
+	return &Recorder{mode: mode, dir: dir}, nil
+}
+
+func (r *Recorder) Mode() string {
+	return r.mode
+}
+
+func (r *Recorder) cassettePath(name string) string {
+	return filepath.Join(r.dir, strings.ReplaceAll(name, "/", "_")+".json")
+}
+
+// Start selects the cassette used for the following requests. In replay mode it fails when the cassette does not exist.
+// The recorder serves a single test at a time, as the requests of the provider can't be told apart by test, so it
+// fails when the cassette of another test is in use, which happens when the tests run in parallel.
+func (r *Recorder) Start(name string, accountId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cassette != nil {
+		return fmt.Errorf("the cassette of %s is in use, tests calling t.Parallel() can't be recorded or replayed", r.name)
+	}
+
+	r.name = name
+	r.mapping = map[string]string{}
+	r.reverse = map[string]string{}
+
+	if r.mode == RecorderModeRecord {
+		r.cassette = &Cassette{AccountId: accountId}
+		return nil
+	}
+
+	data, err := os.ReadFile(r.cassettePath(name))
+	if err != nil {
+		r.cassette = nil
+		return err
+	}
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		r.cassette = nil
+		return fmt.Errorf("error reading cassette %s: %w", r.cassettePath(name), err)
+	}
+	r.cassette = cassette
+	r.used = make([]bool, len(cassette.Interactions))
+	return nil
+}
+
+// Stop saves the cassette in record mode and stops recording.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette := r.cassette
+	r.cassette = nil
+	if r.mode != RecorderModeRecord || cassette == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.cassettePath(r.name), append(data, '\n'), 0644)
+}
+
+// Cassette returns the cassette in use, if any.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette
+}
+
+// Transport returns a RoundTripper recording the requests sent to next, or replaying them without calling next.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	return &recorderTransport{recorder: r, next: next}
+}
+
+type recorderTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.recorder.mode == RecorderModeReplay {
+		return t.recorder.replay(req, body)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.recorder.record(Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    requestURL(req),
+			Header: redactHeader(req.Header),
+			Body:   redactBody(req.Header, body, true),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       redactBody(resp.Header, respBody, false),
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) record(interaction Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cassette != nil {
+		r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	}
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cassette == nil {
+		return nil, &provider.NonRetryableError{Err: errors.New("no cassette loaded, requests can only be replayed from tests calling acctest.TestAccPreCheck")}
+	}
+
+	// The recorded bodies are redacted, so the body is redacted the same way to ignore the redacted fields.
+	url := requestURL(req)
+	body = redactBody(req.Header, body, true)
+	best, bestMapping := -1, map[string]string(nil)
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method {
+			continue
+		}
+		mapping, ok := r.match(interaction.Request, url, body)
+		if ok && (best == -1 || len(mapping) < len(bestMapping)) {
+			best, bestMapping = i, mapping
+			if len(mapping) == 0 {
+				break
+			}
+		}
+	}
+
+	// Requests without side effects can be sent more often than when the cassette was recorded.
+	if best == -1 && req.Method == http.MethodGet {
+		for i := len(r.cassette.Interactions) - 1; i >= 0; i-- {
+			interaction := r.cassette.Interactions[i]
+			if interaction.Request.Method != req.Method {
+				continue
+			}
+			if mapping, ok := r.match(interaction.Request, url, body); ok {
+				best, bestMapping = i, mapping
+				break
+			}
+		}
+	}
+
+	if best == -1 {
+		// Retrying can't find an interaction either, the error fails the test right away.
+		return nil, &provider.NonRetryableError{Err: fmt.Errorf("no interaction recorded in cassette %s for %s %s", r.cassettePath(r.name), req.Method, url)}
+	}
+
+	r.used[best] = true
+	for recorded, actual := range bestMapping {
+		r.mapping[recorded] = actual
+		r.reverse[actual] = recorded
+	}
+
+	recorded := r.cassette.Interactions[best].Response
+	respBody := []byte(substituteTokens(string(recorded.Body), r.mapping))
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Del("Content-Length")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// match returns the new token mappings needed for the recorded request to match the request being sent.
+func (r *Recorder) match(recorded RecordedRequest, url string, body []byte) (map[string]string, bool) {
+	mapping := map[string]string{}
+	if !r.matchTokens(recorded.URL, url, mapping) {
+		return nil, false
+	}
+	if !r.matchTokens(string(recorded.Body), string(body), mapping) {
+		return nil, false
+	}
+	return mapping, true
+}
+
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9]+|[^A-Za-z0-9]+`)
+
+func (r *Recorder) matchTokens(recorded string, actual string, mapping map[string]string) bool {
+	if recorded == actual {
+		return true
+	}
+
+	recordedTokens := tokenPattern.FindAllString(recorded, -1)
+	actualTokens := tokenPattern.FindAllString(actual, -1)
+	if len(recordedTokens) != len(actualTokens) {
+		return false
+	}
+
+	for i, recordedToken := range recordedTokens {
+		actualToken := actualTokens[i]
+		if recordedToken == actualToken {
+			continue
+		}
+		if !isAlphanumeric(recordedToken) || len(recordedToken) != len(actualToken) {
+			return false
+		}
+		if mapped, ok := r.mapping[recordedToken]; ok {
+			if mapped != actualToken {
+				return false
+			}
+			continue
+		}
+		if _, ok := r.reverse[actualToken]; ok {
+			return false
+		}
+		if mapped, ok := mapping[recordedToken]; ok && mapped != actualToken {
+			return false
+		}
+		mapping[recordedToken] = actualToken
+	}
+
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	c := s[0]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func substituteTokens(s string, mapping map[string]string) string {
+	if len(mapping) == 0 {
+		return s
+	}
+	return tokenPattern.ReplaceAllStringFunc(s, func(token string) string {
+		if mapped, ok := mapping[token]; ok {
+			return mapped
+		}
+		return token
+	})
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// requestURL returns the URL without the scheme and host so that the cassettes can be replayed against any endpoint.
+func requestURL(req *http.Request) string {
+	return req.URL.RequestURI()
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, redactedValue)
+		}
+	}
+	return redacted
+}
+
+// redactBody replaces the plaintext secrets of a JSON or form body. The body is only rewritten when it holds one.
+func redactBody(header http.Header, body []byte, request bool) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			redacted := false
+			for key := range form {
+				if isRedactedField(key, request) {
+					form[key] = []string{redactedValue}
+					redacted = true
+				}
+			}
+			if redacted {
+				body = []byte(form.Encode())
+			}
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var v interface{}
+		if err := decoder.Decode(&v); err == nil && redactJSON(v, request) {
+			if data, err := json.Marshal(v); err == nil {
+				body = data
+			}
+		}
+	}
+
+	return apiKeyPattern.ReplaceAll(body, []byte(redactedValue))
+}
+
+// redactJSON replaces the string values of the redacted fields and returns whether any was replaced.
+func redactJSON(v interface{}, request bool) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := value.(string); ok && isRedactedField(key, request) {
+				v[key] = redactedValue
+				redacted = true
+				continue
+			}
+			redacted = redactJSON(value, request) || redacted
+		}
+	case []interface{}:
+		for _, value := range v {
+			redacted = redactJSON(value, request) || redacted
+		}
+	}
+	return redacted
+}
+
+func isRedactedField(key string, request bool) bool {
+	key = strings.ReplaceAll(strings.ToLower(key), "_", "")
+	return redactedFields[key] || (request && key == "value")
+}
+
+var testAccRecorder *Recorder
+
+func init() {
+	mode := os.Getenv(RecorderModeEnvVar)
+	if mode == "" {
+		return
+	}
+
+	dir := os.Getenv(CassetteDirEnvVar)
+	if dir == "" {
+		dir = defaultCassetteDir
+	}
+
+	recorder, err := NewRecorder(mode, dir)
+	if err != nil {
+		panic(err)
+	}
+
+	testAccRecorder = recorder
+	provider.WrapTransport = recorder.Transport
+	// The retries of the recorded rate limits and server errors are replayed from the cassettes without waiting.
+	provider.NoRetryWait = mode == RecorderModeReplay
+}
+
+// useCassette records or replays the interactions of the test with the Harness API when the recorder is enabled.
+// Tests without a cassette are skipped in replay mode.
+func useCassette(t *testing.T) {
+	if testAccRecorder == nil {
+		return
+	}
+
+	if err := testAccRecorder.Start(t.Name(), helpers.EnvVars.AccountId.Get()); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			t.Skipf("no cassette recorded for %s", t.Name())
+		}
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := testAccRecorder.Stop(); err != nil {
+			t.Errorf("error saving cassette: %s", err)
+		}
+	})
+
+	// The credentials are not needed to replay the interactions, the provider is configured with the recorded account.
+	if testAccRecorder.Mode() == RecorderModeReplay {
+		t.Setenv(helpers.EnvVars.AccountId.String(), testAccRecorder.Cassette().AccountId)
+		for _, envVar := range []helpers.EnvVar{helpers.EnvVars.ApiKey, helpers.EnvVars.PlatformApiKey} {
+			if envVar.Get() == "" {
+				t.Setenv(envVar.String(), "replay")
+			}
+		}
+	}
+}
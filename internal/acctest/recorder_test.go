@@ -0,0 +1,147 @@
+package acctest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/provider"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/secret"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"data":{"project":` + string(body) + `}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"project":{"identifier":"` + strings.TrimPrefix(r.URL.Path, "/ng/api/projects/") + `","name":"Test"}}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	send := func(transport http.RoundTripper, method string, path string, body string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-Api-Key", "secret")
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(respBody)
+	}
+
+	// Record
+	recorder, err := NewRecorder(RecorderModeRecord, dir)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Start("TestAccProject", "account"))
+	transport := recorder.Transport(http.DefaultTransport)
+	send(transport, http.MethodPost, "/ng/api/projects?accountIdentifier=account", `{"identifier":"TestAccProject_abcde"}`)
+	send(transport, http.MethodGet, "/ng/api/projects/TestAccProject_abcde?accountIdentifier=account", "")
+	require.NoError(t, recorder.Stop())
+	server.Close()
+
+	// Replay with another random identifier and without network access
+	recorder, err = NewRecorder(RecorderModeReplay, dir)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Start("TestAccProject", "account"))
+	require.Equal(t, "account", recorder.Cassette().AccountId)
+	require.Equal(t, redactedValue, recorder.Cassette().Interactions[0].Request.Header.Get("X-Api-Key"))
+	transport = recorder.Transport(nil)
+
+	status, body := send(transport, http.MethodPost, "/ng/api/projects?accountIdentifier=account", `{"identifier":"TestAccProject_vwxyz"}`)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, `{"data":{"project":{"identifier":"TestAccProject_vwxyz"}}}`, body)
+
+	// The GET request can be replayed more than once
+	for i := 0; i < 2; i++ {
+		status, body = send(transport, http.MethodGet, "/ng/api/projects/TestAccProject_vwxyz?accountIdentifier=account", "")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, `{"data":{"project":{"identifier":"TestAccProject_vwxyz","name":"Test"}}}`, body)
+	}
+
+	// The mapping of the random identifier is kept for the whole cassette
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/ng/api/projects/TestAccProject_other?accountIdentifier=account", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	var nonRetryable *provider.NonRetryableError
+	require.ErrorAs(t, err, &nonRetryable)
+
+	// Unknown cassettes
+	require.Error(t, recorder.Start("TestAccUnknown", "account"))
+}
+
+func TestRecorder_redactsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	wrapTransport := provider.WrapTransport
+	t.Cleanup(func() { provider.WrapTransport = wrapTransport })
+
+	createSecret := func(recorder *Recorder, value string) {
+		require.NoError(t, recorder.Start("TestAccSecretText", fakeserver.AccountId))
+		provider.WrapTransport = recorder.Transport
+		server := fakeserver.New(t)
+		session := server.Session(t)
+
+		r := secret.ResourceSecretText()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"identifier":                "secret",
+			"name":                      "Secret",
+			"secret_manager_identifier": "harnessSecretManager",
+			"value_type":                "Inline",
+			"value":                     value,
+		})
+		diags := r.CreateContext(context.Background(), d, session)
+		require.False(t, diags.HasError(), diags)
+		require.NoError(t, recorder.Stop())
+	}
+
+	recorder, err := NewRecorder(RecorderModeRecord, dir)
+	require.NoError(t, err)
+	createSecret(recorder, "plaintext-s3cr3t")
+
+	data, err := os.ReadFile(recorder.cassettePath("TestAccSecretText"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "plaintext-s3cr3t")
+	require.Contains(t, string(data), redactedValue)
+
+	// The redacted value is ignored when replaying.
+	recorder, err = NewRecorder(RecorderModeReplay, dir)
+	require.NoError(t, err)
+	createSecret(recorder, "another-value")
+}
+
+func TestRecorder_redactBody(t *testing.T) {
+	json := http.Header{"Content-Type": {"application/json"}}
+	form := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+
+	require.Equal(t, `{"secret":{"spec":{"value":"REDACTED","valueType":"Inline"}}}`,
+		string(redactBody(json, []byte(`{"secret":{"spec":{"valueType":"Inline","value":"s3cr3t"}}}`), true)))
+	require.Equal(t, `{"spec":{"passwordRef":"account.password","value":"1"}}`,
+		string(redactBody(json, []byte(`{"spec":{"passwordRef":"account.password","value":"1"}}`), false)))
+	require.Equal(t, `{"access_token":"REDACTED","expires_in":3600}`,
+		string(redactBody(json, []byte(`{"access_token":"harness-token","expires_in":3600}`), false)))
+	require.Equal(t, `{"status":"SUCCESS","data":"REDACTED"}`,
+		string(redactBody(json, []byte(`{"status":"SUCCESS","data":"pat.account.token.s3cr3t"}`), false)))
+	require.Equal(t, "grant_type=exchange&subject_token=REDACTED",
+		string(redactBody(form, []byte("grant_type=exchange&subject_token=jwt"), true)))
+}
+
+func TestRecorder_failsInParallel(t *testing.T) {
+	recorder, err := NewRecorder(RecorderModeRecord, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, recorder.Start("TestAccFirst", "account"))
+	require.EqualError(t, recorder.Start("TestAccSecond", "account"), "the cassette of TestAccFirst is in use, tests calling t.Parallel() can't be recorded or replayed")
+	require.NoError(t, recorder.Stop())
+	require.NoError(t, recorder.Start("TestAccSecond", "account"))
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	tokenExchangeGrantType   = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenExchangeSubjectType = "urn:ietf:params:oauth:token-type:jwt"
+
+	// tokenRefreshWindow is how long before its expiry a short-lived token is refreshed.
+	tokenRefreshWindow = time.Minute
+	// defaultTokenLifetime is used when the token exchange response does not include expires_in.
+	defaultTokenLifetime = 5 * time.Minute
+)
+
+// tokenSource returns the token used to authenticate the requests to the Harness NextGen APIs.
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// getPlatformTokenSource returns the token source configured with platform_api_key_file or the oidc block,
+// or nil when the platform_api_key argument is used.
+func getPlatformTokenSource(d *schema.ResourceData, transport http.RoundTripper) (tokenSource, error) {
+	if _, ok := d.GetOk("oidc"); ok {
+		return &oidcTokenSource{
+			accountId:   d.Get("account_id").(string),
+			exchangeUrl: d.Get("oidc.0.token_exchange_url").(string),
+			tokenFile:   d.Get("oidc.0.token_file").(string),
+			tokenEnvVar: d.Get("oidc.0.token_env_var").(string),
+			httpClient:  &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		}, nil
+	}
+
+	if path := d.Get("platform_api_key_file").(string); path != "" {
+		return &fileTokenSource{path: path}, nil
+	}
+
+	return nil, nil
+}
+
+// fileTokenSource reads the API key from a file, the file is read again when it is modified so that the key
+// can be rotated without restarting Terraform.
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+func (s *fileTokenSource) Token(_ context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading platform_api_key_file: %w", err)
+	}
+	if s.token != "" && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading platform_api_key_file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("platform_api_key_file %s is empty", s.path)
+	}
+
+	s.token = token
+	s.modTime = info.ModTime()
+	return s.token, nil
+}
+
+// oidcTokenSource exchanges an OIDC token issued by the CI system for a short-lived Harness token using the
+// OAuth 2.0 token exchange grant (RFC 8693), and exchanges it again shortly before it expires.
+type oidcTokenSource struct {
+	accountId   string
+	exchangeUrl string
+	tokenFile   string
+	tokenEnvVar string
+	httpClient  *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+type tokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (s *oidcTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expiry) > tokenRefreshWindow {
+		return s.token, nil
+	}
+
+	subjectToken, err := s.subjectToken()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":         {tokenExchangeGrantType},
+		"subject_token":      {subjectToken},
+		"subject_token_type": {tokenExchangeSubjectType},
+		"account_id":         {s.accountId},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.exchangeUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error exchanging the oidc token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading the oidc token exchange response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc token exchange failed with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result tokenExchangeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("error parsing the oidc token exchange response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("oidc token exchange response does not contain an access_token")
+	}
+
+	lifetime := defaultTokenLifetime
+	if result.ExpiresIn > 0 {
+		lifetime = time.Duration(result.ExpiresIn) * time.Second
+	}
+
+	s.token = result.AccessToken
+	s.expiry = time.Now().Add(lifetime)
+	return s.token, nil
+}
+
+// subjectToken reads the OIDC token issued by the CI system. It is read on every exchange because CI systems
+// rotate the file or the environment variable.
+func (s *oidcTokenSource) subjectToken() (string, error) {
+	var token string
+	if s.tokenFile != "" {
+		data, err := os.ReadFile(s.tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading the oidc token_file: %w", err)
+		}
+		token = string(data)
+	} else if s.tokenEnvVar != "" {
+		token = os.Getenv(s.tokenEnvVar)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("no oidc token found, set the oidc token_file or token_env_var")
+	}
+	return token, nil
+}
+
+// apiKeyTransport sets the token returned by the token source on every request.
+type apiKeyTransport struct {
+	source tokenSource
+	next   http.RoundTripper
+}
+
+func newApiKeyTransport(source tokenSource, next http.RoundTripper) *apiKeyTransport {
+	return &apiKeyTransport{source: source, next: next}
+}
+
+func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Api-Key", token)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(path, []byte("pat.account.token.secret\n"), 0600))
+
+	source := &fileTokenSource{path: path}
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "pat.account.token.secret", token)
+
+	// The file is read again when it is rotated
+	require.NoError(t, os.WriteFile(path, []byte("pat.account.token.rotated"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "pat.account.token.rotated", token)
+
+	require.NoError(t, os.WriteFile(path, []byte(""), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	_, err = source.Token(context.Background())
+	require.Error(t, err)
+}
+
+func TestOidcTokenSource(t *testing.T) {
+	var exchanges int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, tokenExchangeGrantType, r.PostForm.Get("grant_type"))
+		require.Equal(t, tokenExchangeSubjectType, r.PostForm.Get("subject_token_type"))
+		require.Equal(t, "account", r.PostForm.Get("account_id"))
+		if r.PostForm.Get("subject_token") != "ci-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		n := atomic.AddInt32(&exchanges, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"harness-token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_OIDC_TOKEN", "ci-token")
+	source := &oidcTokenSource{
+		accountId:   "account",
+		exchangeUrl: server.URL,
+		tokenEnvVar: "TEST_OIDC_TOKEN",
+		httpClient:  server.Client(),
+	}
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "harness-token-1", token)
+
+	// The token is cached until it is about to expire
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "harness-token-1", token)
+
+	source.expiry = time.Now().Add(tokenRefreshWindow / 2)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "harness-token-2", token)
+
+	t.Setenv("TEST_OIDC_TOKEN", "invalid")
+	source.expiry = time.Now()
+	_, err = source.Token(context.Background())
+	require.Error(t, err)
+
+	t.Setenv("TEST_OIDC_TOKEN", "")
+	_, err = source.Token(context.Background())
+	require.Error(t, err)
+}
+
+func TestConfigure_oidc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/exchange" {
+			w.Write([]byte(`{"access_token":"harness-token","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("X-Api-Key") != "harness-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":"SUCCESS","data":{"identifier":"account"}}`))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("ci-token"), 0600))
+
+	p := Provider("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"endpoint":    server.URL,
+		"account_id":  "account",
+		"api_key":     "key",
+		"max_retries": 0,
+		"oidc": []interface{}{
+			map[string]interface{}{
+				"token_file":         tokenFile,
+				"token_exchange_url": server.URL + "/exchange",
+			},
+		},
+	}))
+	require.False(t, diags.HasError(), diags)
+}
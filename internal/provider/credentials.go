@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateCredentials checks that the configured credentials can be used with the configured account before
+// any resource is planned or applied.
+func validateCredentials(ctx context.Context, d *schema.ResourceData, session *internal.Session, platformApiKey string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	accountId := d.Get("account_id").(string)
+	apiKey := d.Get("api_key").(string)
+
+	if apiKey == "" && platformApiKey != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "api_key is not set",
+			Detail:   "Only platform_api_key is configured, FirstGen resources require api_key to be set.",
+		})
+	}
+	if platformApiKey == "" && apiKey != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "platform_api_key is not set",
+			Detail:   "Only api_key is configured, NextGen resources require platform_api_key to be set.",
+		})
+	}
+
+	if platformApiKey == "" {
+		return diags
+	}
+
+	if accountId == "" {
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "account_id is not set",
+			Detail:        "The account_id argument or the HARNESS_ACCOUNT_ID environment variable must be set when platform_api_key is configured.",
+			AttributePath: cty.GetAttrPath("account_id"),
+		})
+	}
+
+	if tokenAccountId, ok := getTokenAccountId(platformApiKey); ok && tokenAccountId != accountId {
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "platform_api_key does not belong to account_id",
+			Detail:        fmt.Sprintf("The platform_api_key was issued for account %s but account_id is set to %s.", tokenAccountId, accountId),
+			AttributePath: cty.GetAttrPath("platform_api_key"),
+		})
+	}
+
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+	resp, httpResp, err := c.AccountsApi.GetAccountNG(ctx, accountId)
+	if err != nil {
+		if httpResp == nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to reach the Harness API",
+				Detail:   fmt.Sprintf("Validating the credentials against %s failed: %s", session.Endpoint, err),
+			})
+		}
+
+		switch httpResp.StatusCode {
+		case http.StatusUnauthorized:
+			return append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid platform_api_key",
+				Detail:        fmt.Sprintf("The Harness API rejected the platform_api_key for account %s (%s). Check that the token has not expired or been revoked.", accountId, httpResp.Status),
+				AttributePath: cty.GetAttrPath("platform_api_key"),
+			})
+		case http.StatusForbidden:
+			// The token is valid but is not allowed to view the account, resources will report their own permission errors.
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to validate credentials",
+				Detail:   fmt.Sprintf("The platform_api_key is not allowed to view account %s (%s), the credentials could not be fully validated.", accountId, httpResp.Status),
+			})
+		case http.StatusNotFound, http.StatusBadRequest:
+			return append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid account_id",
+				Detail:        fmt.Sprintf("Account %s was not found with the configured platform_api_key (%s).", accountId, httpResp.Status),
+				AttributePath: cty.GetAttrPath("account_id"),
+			})
+		}
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to validate credentials",
+			Detail:   fmt.Sprintf("Validating the credentials for account %s failed: %s", accountId, err),
+		})
+	}
+
+	if resp.Data != nil && resp.Data.Identifier != "" && resp.Data.Identifier != accountId {
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "platform_api_key does not belong to account_id",
+			Detail:        fmt.Sprintf("The platform_api_key resolved to account %s but account_id is set to %s.", resp.Data.Identifier, accountId),
+			AttributePath: cty.GetAttrPath("platform_api_key"),
+		})
+	}
+
+	return diags
+}
+
+// getTokenAccountId returns the account identifier embedded in personal access tokens and service account
+// tokens, which have the format <pat|sat>.<account_id>.<token_id>.<secret>.
+func getTokenAccountId(token string) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 || (parts[0] != "pat" && parts[0] != "sat") {
+		return "", false
+	}
+	return parts[1], true
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure_validateCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "pat.account.token.secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"SUCCESS","data":{"identifier":"account"}}`))
+	}))
+	defer server.Close()
+
+	configure := func(config map[string]interface{}) (bool, int) {
+		config["endpoint"] = server.URL
+		config["max_retries"] = 0
+		p := Provider("dev")()
+		diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+		return diags.HasError(), len(diags)
+	}
+
+	hasError, _ := configure(map[string]interface{}{
+		"account_id":       "account",
+		"api_key":          "key",
+		"platform_api_key": "pat.account.token.secret",
+	})
+	require.False(t, hasError)
+
+	// Only platform_api_key is set
+	hasError, count := configure(map[string]interface{}{
+		"account_id":       "account",
+		"platform_api_key": "pat.account.token.secret",
+	})
+	require.False(t, hasError)
+	require.Equal(t, 1, count)
+
+	// Token issued for another account
+	hasError, _ = configure(map[string]interface{}{
+		"account_id":       "other",
+		"platform_api_key": "pat.account.token.secret",
+	})
+	require.True(t, hasError)
+
+	// Token rejected by the API
+	hasError, _ = configure(map[string]interface{}{
+		"account_id":       "account",
+		"platform_api_key": "pat.account.token.invalid",
+	})
+	require.True(t, hasError)
+
+	// Validation disabled
+	hasError, _ = configure(map[string]interface{}{
+		"account_id":           "account",
+		"platform_api_key":     "pat.account.token.invalid",
+		"validate_credentials": false,
+	})
+	require.False(t, hasError)
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/secret"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/token"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns a server combining the SDK provider with the framework provider, so that resources can be
+// implemented with either of them. Both providers share the same configuration and session.
+func ProviderServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := Provider(version)()
+	frameworkProvider, err := NewFrameworkProvider(version, sdkProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// The SDK provider must come first: it is configured before the framework provider, which reuses its session.
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(frameworkProvider),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// FrameworkProvider is the terraform-plugin-framework provider serving the resources, data sources and ephemeral
+// resources that are implemented with the framework.
+type FrameworkProvider struct {
+	version     string
+	sdkProvider *schema.Provider
+	schema      fwschema.Schema
+}
+
+var (
+	_ fwprovider.Provider                       = &FrameworkProvider{}
+	_ fwprovider.ProviderWithEphemeralResources = &FrameworkProvider{}
+)
+
+// NewFrameworkProvider returns the framework provider served alongside the given SDK provider. Its schema is
+// derived from the schema of the SDK provider as both must be identical, and it uses the session configured by
+// the SDK provider.
+func NewFrameworkProvider(version string, sdkProvider *schema.Provider) (*FrameworkProvider, error) {
+	resp, err := sdkProvider.GRPCProvider().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("error getting the provider schema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	attributes, blocks, err := frameworkProviderSchema(resp.Provider.Block, sdkProvider.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FrameworkProvider{
+		version:     version,
+		sdkProvider: sdkProvider,
+		schema: fwschema.Schema{
+			Description:         resp.Provider.Block.Description,
+			MarkdownDescription: markdownDescription(resp.Provider.Block.Description, resp.Provider.Block.DescriptionKind),
+			Attributes:          attributes,
+			Blocks:              blocks,
+		},
+	}, nil
+}
+
+func (p *FrameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "harness"
+	resp.Version = p.version
+}
+
+func (p *FrameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = p.schema
+}
+
+// Configure shares the session of the SDK provider with the resources of the framework provider.
+func (p *FrameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	session, ok := p.sdkProvider.Meta().(*internal.Session)
+	if !ok || session == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The framework provider is configured with the session of the SDK provider, which has not been configured.")
+		return
+	}
+
+	resp.ResourceData = session
+	resp.DataSourceData = session
+	resp.EphemeralResourceData = session
+}
+
+func (p *FrameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+func (p *FrameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *FrameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		secret.NewEphemeralSecretText,
+		token.NewEphemeralToken,
+	}
+}
+
+// frameworkProviderSchema converts the protocol schema of the SDK provider to the attributes and blocks of the
+// framework provider schema. The SDK schema it was generated from provides the deprecation messages, the protocol
+// schema only reporting whether the attributes and blocks are deprecated.
+func frameworkProviderSchema(block *tfprotov5.SchemaBlock, sdkSchema map[string]*schema.Schema) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := map[string]fwschema.Attribute{}
+	for _, a := range block.Attributes {
+		attribute, err := frameworkProviderAttribute(a, sdkSchema[a.Name].Deprecated)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", a.Name, err)
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := map[string]fwschema.Block{}
+	for _, b := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkProviderSchema(b.Block, sdkSchema[b.TypeName].Elem.(*schema.Resource).Schema)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", b.TypeName, err)
+		}
+		object := fwschema.NestedBlockObject{Attributes: nestedAttributes, Blocks: nestedBlocks}
+		description := b.Block.Description
+		markdown := markdownDescription(b.Block.Description, b.Block.DescriptionKind)
+		deprecation := sdkSchema[b.TypeName].Deprecated
+
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[b.TypeName] = fwschema.ListNestedBlock{NestedObject: object, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[b.TypeName] = fwschema.SetNestedBlock{NestedObject: object, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[b.TypeName] = fwschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}
+		default:
+			return nil, nil, fmt.Errorf("%s: unsupported nesting mode %s", b.TypeName, b.Nesting)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkProviderAttribute(a *tfprotov5.SchemaAttribute, deprecation string) (fwschema.Attribute, error) {
+	description := a.Description
+	markdown := markdownDescription(a.Description, a.DescriptionKind)
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.List{}):
+		elementType, err := frameworkElementType(a.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.ListAttribute{ElementType: elementType, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Set{}):
+		elementType, err := frameworkElementType(a.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.SetAttribute{ElementType: elementType, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Map{}):
+		elementType, err := frameworkElementType(a.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.MapAttribute{ElementType: elementType, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", a.Type)
+}
+
+func frameworkElementType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	}
+	return nil, fmt.Errorf("unsupported element type %s", t)
+}
+
+// markdownDescription returns the description as the markdown description of the framework schema when the SDK
+// describes it as markdown, which is the case of all the descriptions of this provider.
+func markdownDescription(description string, kind tfprotov5.StringKind) string {
+	if kind == tfprotov5.StringKindMarkdown {
+		return description
+	}
+	return ""
+}
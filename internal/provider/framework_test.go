@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderServer(t *testing.T) {
+	ctx := context.Background()
+
+	sdkProvider := Provider("dev")()
+	frameworkProvider, err := NewFrameworkProvider("dev", sdkProvider)
+	require.NoError(t, err)
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, sdkProvider.GRPCProvider, providerserver.NewProtocol5(frameworkProvider))
+	require.NoError(t, err)
+	server := muxServer.ProviderServer()
+
+	// The mux server rejects providers whose schemas are not identical.
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemaResp.Diagnostics)
+
+	objectType := schemaResp.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, t := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(t, nil)
+	}
+	values["endpoint"] = tftypes.NewValue(tftypes.String, "https://app.harness.io/gateway")
+	values["account_id"] = tftypes.NewValue(tftypes.String, "account")
+	values["platform_api_key"] = tftypes.NewValue(tftypes.String, "pat.account.token.secret")
+	values["validate_credentials"] = tftypes.NewValue(tftypes.Bool, false)
+	config, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	require.NoError(t, err)
+
+	// The SDK provider is configured first, so that the framework provider reuses its session.
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &config})
+	require.NoError(t, err)
+	require.Empty(t, configureResp.Diagnostics)
+	require.Equal(t, "account", sdkProvider.Meta().(*internal.Session).AccountId)
+
+	// The framework provider fails to configure when the session of the SDK provider is not available.
+	frameworkProvider, err = NewFrameworkProvider("dev", Provider("dev")())
+	require.NoError(t, err)
+	frameworkServer := providerserver.NewProtocol5(frameworkProvider)()
+	configureResp, err = frameworkServer.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &config})
+	require.NoError(t, err)
+	require.Len(t, configureResp.Diagnostics, 1)
+	require.Equal(t, "Provider not configured", configureResp.Diagnostics[0].Summary)
+}
+
+func TestFrameworkProviderSchema_deprecation(t *testing.T) {
+	sdkSchema := map[string]*schema.Schema{
+		"key": {
+			Type:       schema.TypeString,
+			Optional:   true,
+			Deprecated: "Use key_file instead.",
+		},
+		"auth": {
+			Type:       schema.TypeList,
+			Optional:   true,
+			Deprecated: "Use oidc instead.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"token": {Type: schema.TypeString, Optional: true, Deprecated: "Use token_file instead."},
+			}},
+		},
+	}
+
+	p, err := NewFrameworkProvider("dev", &schema.Provider{Schema: sdkSchema})
+	require.NoError(t, err)
+	require.Equal(t, "Use key_file instead.", p.schema.Attributes["key"].GetDeprecationMessage())
+	require.Equal(t, "Use oidc instead.", p.schema.Blocks["auth"].GetDeprecationMessage())
+	require.Equal(t, "Use token_file instead.", p.schema.Blocks["auth"].(fwschema.ListNestedBlock).NestedObject.Attributes["token"].GetDeprecationMessage())
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/harness-go-sdk/harness"
+	"github.com/harness/harness-go-sdk/logging"
+	openapi_client_logging "github.com/harness/harness-openapi-go-client/logging"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	defaultMaxRetries   = 10
+	defaultRetryWaitMin = 1
+	defaultRetryWaitMax = 30
+)
+
+// WrapTransport, when set, wraps the transport shared by all the clients of the provider. It is used by the
+// acceptance tests to record and replay the interactions with the Harness API.
+var WrapTransport func(http.RoundTripper) http.RoundTripper
+
+// NoRetryWait, when set, makes the clients of the provider retry the requests without waiting. It is used by the
+// acceptance tests replaying recorded interactions, whose recorded rate limits and server errors don't need a wait.
+var NoRetryWait bool
+
+// NonRetryableError is returned by the transports set with WrapTransport for the requests that would fail the same
+// way when retried.
+type NonRetryableError struct {
+	Err error
+}
+
+func (e *NonRetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NonRetryableError) Unwrap() error {
+	return e.Err
+}
+
+// getTransport returns the transport shared by all the clients of the provider.
+func getTransport(d *schema.ResourceData) (http.RoundTripper, error) {
+	pooled := cleanhttp.DefaultPooledTransport()
+
+	tlsConfig, err := getTLSConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		pooled.TLSClientConfig = tlsConfig
+	}
+
+	if proxyUrl := d.Get("proxy.0.url").(string); proxyUrl != "" {
+		u, err := url.Parse(proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", proxyUrl, err)
+		}
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  u.String(),
+			HTTPSProxy: u.String(),
+			NoProxy:    d.Get("proxy.0.no_proxy").(string),
+		}).ProxyFunc()
+		pooled.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	var transport http.RoundTripper = pooled
+	if limit := d.Get("max_concurrent_requests").(int); limit > 0 {
+		transport = newConcurrencyLimitTransport(limit, transport)
+	}
+
+	if WrapTransport != nil {
+		transport = WrapTransport(transport)
+	}
+
+	return transport, nil
+}
+
+func getTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	if _, ok := d.GetOk("tls"); !ok {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: d.Get("tls.0.insecure_skip_verify").(bool),
+	}
+
+	caPem := []byte(d.Get("tls.0.ca_pem").(string))
+	if caFile := d.Get("tls.0.ca_file").(string); caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading tls ca_file: %w", err)
+		}
+		caPem = append(caPem, '\n')
+		caPem = append(caPem, data...)
+	}
+	if len(bytes.TrimSpace(caPem)) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("no valid certificates found in the tls ca_file or ca_pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certFile := d.Get("tls.0.client_cert_file").(string)
+	keyFile := d.Get("tls.0.client_key_file").(string)
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func getHttpClient(d *schema.ResourceData, logger *logrus.Logger, transport http.RoundTripper) *retryablehttp.Client {
+	httpClient := newRetryableClient(d)
+	httpClient.HTTPClient.Transport = logging.NewTransport(harness.SDKName, logger, transport)
+	return httpClient
+}
+
+func getOpenApiHttpClient(d *schema.ResourceData, logger *logrus.Logger, transport http.RoundTripper) *retryablehttp.Client {
+	httpClient := newRetryableClient(d)
+	httpClient.HTTPClient.Transport = openapi_client_logging.NewTransport(harness.SDKName, logger, transport)
+	return httpClient
+}
+
+func newRetryableClient(d *schema.ResourceData) *retryablehttp.Client {
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = d.Get("max_retries").(int)
+	httpClient.RetryWaitMin = time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+	httpClient.RetryWaitMax = time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+	httpClient.CheckRetry = retryPolicy
+	httpClient.Backoff = backoff
+	if NoRetryWait {
+		httpClient.Backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration { return 0 }
+	}
+	return httpClient
+}
+
+// retryPolicy retries on connection errors, rate limiting and server errors.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	var nonRetryable *NonRetryableError
+	if errors.As(err, &nonRetryable) {
+		return false, err
+	}
+
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		log.Printf("[WARN] %s %s returned %s, retrying", resp.Request.Method, resp.Request.URL.Path, resp.Status)
+		return true, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// backoff waits for the duration requested by the Retry-After header on 429 and 503 responses, up to max, and
+// uses an exponential backoff with jitter otherwise.
+func backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > max {
+				wait = max
+			}
+			return wait
+		}
+	}
+
+	wait := retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait = wait - time.Duration(jitter) + time.Duration(rand.Int63n(jitter*2))
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// concurrencyLimitTransport bounds the number of requests in flight. A request holds its slot until the
+// response body is closed, retries release the slot while they wait for the backoff.
+type concurrencyLimitTransport struct {
+	slots chan struct{}
+	next  http.RoundTripper
+}
+
+func newConcurrencyLimitTransport(limit int, next http.RoundTripper) *concurrencyLimitTransport {
+	return &concurrencyLimitTransport{
+		slots: make(chan struct{}, limit),
+		next:  next,
+	}
+}
+
+func (t *concurrencyLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		t.release()
+		return resp, err
+	}
+
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: t.release}
+	return resp, nil
+}
+
+func (t *concurrencyLimitTransport) release() {
+	<-t.slots
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
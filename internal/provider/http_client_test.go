@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("5")
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.InDelta(t, time.Minute, wait, float64(2*time.Second))
+
+	_, ok = parseRetryAfter("")
+	require.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
+
+func TestBackoff(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"7"}}}
+	require.Equal(t, 7*time.Second, backoff(time.Second, 30*time.Second, 1, resp))
+	require.Equal(t, 5*time.Second, backoff(time.Second, 5*time.Second, 1, resp))
+
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := backoff(time.Second, 30*time.Second, attempt, nil)
+		require.LessOrEqual(t, wait, 30*time.Second)
+		require.Greater(t, wait, time.Duration(0))
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	retry, err := retryPolicy(ctx, nil, &url.Error{Op: "Get", URL: "http://localhost", Err: errors.New("connection refused")})
+	require.True(t, retry)
+	require.NoError(t, err)
+
+	retry, err = retryPolicy(ctx, &http.Response{StatusCode: http.StatusTooManyRequests, Request: &http.Request{URL: &url.URL{}}}, nil)
+	require.True(t, retry)
+	require.NoError(t, err)
+
+	nonRetryable := &url.Error{Op: "Get", URL: "http://localhost", Err: &NonRetryableError{Err: errors.New("no interaction recorded")}}
+	retry, err = retryPolicy(ctx, nil, nonRetryable)
+	require.False(t, retry)
+	require.Equal(t, nonRetryable, err)
+}
+
+func TestConfigure_retryWait(t *testing.T) {
+	p := Provider("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":     "account",
+		"retry_wait_min": 10,
+		"retry_wait_max": 5,
+	}))
+	require.True(t, diags.HasError())
+	require.Equal(t, "retry_wait_min (10) must be less than or equal to retry_wait_max (5)", diags[0].Summary)
+}
+
+func TestConcurrencyLimitTransport(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newConcurrencyLimitTransport(2, http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestGetTransport_tls(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{"ca_pem": string(caPem)}},
+	})
+	transport, err := getTransport(d)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	d = schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{})
+	transport, err = getTransport(d)
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.Error(t, err)
+
+	d = schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{"ca_pem": "invalid"}},
+	})
+	_, err = getTransport(d)
+	require.Error(t, err)
+}
+
+func TestGetTransport_proxy(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"proxy": []interface{}{map[string]interface{}{
+			"url":      "http://proxy.example.com:3128",
+			"no_proxy": "internal.example.com",
+		}},
+	})
+	transport, err := getTransport(d)
+	require.NoError(t, err)
+
+	proxy := transport.(*http.Transport).Proxy
+
+	req, _ := http.NewRequest(http.MethodGet, "https://app.harness.io/gateway", nil)
+	proxyUrl, err := proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:3128", proxyUrl.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "https://internal.example.com/gateway", nil)
+	proxyUrl, err = proxy(req)
+	require.NoError(t, err)
+	require.Nil(t, proxyUrl)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/harness/harness-go-sdk/harness/har"
 	"log"
+	"net/http"
 
 	"github.com/harness/harness-go-sdk/harness/chaos"
 	cdng_service "github.com/harness/terraform-provider-harness/internal/service/cd_nextgen/service"
@@ -30,14 +31,13 @@ import (
 	"github.com/harness/terraform-provider-harness/internal/service/platform/ff_api_key"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/gitops/agent_yaml"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/iacm"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/import_blocks"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/policy"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/policyset"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/repo_rule_branch"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/repo_webhook"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/workspace"
-	"github.com/sirupsen/logrus"
 
-	"github.com/harness/harness-go-sdk/harness"
 	"github.com/harness/harness-go-sdk/harness/cd"
 	"github.com/harness/harness-go-sdk/harness/code"
 	"github.com/harness/harness-go-sdk/harness/dbops"
@@ -45,6 +45,7 @@ import (
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	"github.com/harness/harness-go-sdk/harness/utils"
 	openapi_client_nextgen "github.com/harness/harness-openapi-go-client/nextgen"
+	tf_helpers "github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/harness/terraform-provider-harness/internal/service/cd/account"
 	"github.com/harness/terraform-provider-harness/internal/service/cd/application"
@@ -114,10 +115,9 @@ import (
 
 	"github.com/harness/harness-go-sdk/logging"
 	openapi_client_logging "github.com/harness/harness-openapi-go-client/logging"
-	"github.com/hashicorp/go-cleanhttp"
-	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -164,6 +164,177 @@ func Provider(version string) func() *schema.Provider {
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc(helpers.EnvVars.PlatformApiKey.String(), nil),
 				},
+				"platform_api_key_file": {
+					Description: "Path to a file containing the API key for the Harness next gen platform. The file is read again when it changes, which allows the key to be rotated by an external agent. Takes precedence over `platform_api_key`. This can also be set using the `HARNESS_PLATFORM_API_KEY_FILE` environment variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("HARNESS_PLATFORM_API_KEY_FILE", nil),
+				},
+				"oidc": {
+					Description:   "Authenticate to the Harness next gen platform with an OIDC token issued by the CI system (workload identity) instead of a long lived API key. The token is exchanged for a short-lived Harness token which is refreshed before it expires. Takes precedence over `platform_api_key`.",
+					Type:          schema.TypeList,
+					MaxItems:      1,
+					Optional:      true,
+					ConflictsWith: []string{"platform_api_key_file"},
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"token_file": {
+								Description:  "Path to the file containing the OIDC token, such as the projected service account token in Kubernetes.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								ExactlyOneOf: []string{"oidc.0.token_file", "oidc.0.token_env_var"},
+							},
+							"token_env_var": {
+								Description:  "Name of the environment variable containing the OIDC token.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								ExactlyOneOf: []string{"oidc.0.token_file", "oidc.0.token_env_var"},
+							},
+							"token_exchange_url": {
+								Description:  "URL of the OAuth 2.0 token exchange (RFC 8693) endpoint that exchanges the OIDC token for a Harness token.",
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							},
+						},
+					},
+				},
+				"max_retries": {
+					Description:  fmt.Sprintf("Maximum number of times a request to the Harness API is retried on connection errors, rate limiting (429) and server errors (5xx). Defaults to `%d`.", defaultMaxRetries),
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultMaxRetries,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"retry_wait_min": {
+					Description:  fmt.Sprintf("Minimum time in seconds to wait between retries. Defaults to `%d`.", defaultRetryWaitMin),
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryWaitMin,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"retry_wait_max": {
+					Description:  fmt.Sprintf("Maximum time in seconds to wait between retries. The wait requested by the `Retry-After` header of 429 and 503 responses is honored up to this maximum. Must be greater than or equal to `retry_wait_min`. Defaults to `%d`.", defaultRetryWaitMax),
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryWaitMax,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"max_concurrent_requests": {
+					Description:  "Maximum number of requests sent to the Harness API at the same time, shared by all the clients of the provider regardless of the Terraform parallelism. Defaults to `0` which means no limit.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"tls": {
+					Description: "TLS settings used to connect to the Harness API, for example for Harness Self-Managed Platform installations using an internal certificate authority.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ca_file": {
+								Description: "Path to a PEM encoded certificate authority bundle trusted in addition to the system certificates.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"ca_pem": {
+								Description: "PEM encoded certificate authority bundle trusted in addition to the system certificates.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"client_cert_file": {
+								Description:  "Path to a PEM encoded client certificate used for mutual TLS.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								RequiredWith: []string{"tls.0.client_key_file"},
+							},
+							"client_key_file": {
+								Description:  "Path to the PEM encoded private key of the client certificate.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								RequiredWith: []string{"tls.0.client_cert_file"},
+							},
+							"insecure_skip_verify": {
+								Description: "Skip the verification of the server certificate. This should only be used for testing.",
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     false,
+							},
+						},
+					},
+				},
+				"proxy": {
+					Description: "HTTP proxy used to connect to the Harness API. When not set the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"url": {
+								Description:  "URL of the proxy, for example `http://proxy.example.com:3128`.",
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+							},
+							"no_proxy": {
+								Description: "Comma separated list of hosts that are reached without the proxy.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+						},
+					},
+				},
+				"validate_credentials": {
+					Description: "Validate the credentials against the account when the provider is configured, before any resource is planned or applied. Defaults to `true`.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"verify_references": {
+					Description: "Verify at plan time that the connectors and secrets referenced by the resources exist, so that a typo in a reference fails the plan instead of the apply. References that are not known until apply and references inside YAML attributes, like the pipeline YAML, are not verified. Only the references of created resources and the changed references of updated resources are verified, each entity being read once per run. Defaults to `false`. This can also be set using the `HARNESS_VERIFY_REFERENCES` environment variable.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("HARNESS_VERIFY_REFERENCES", false),
+				},
+				"verify_yaml": {
+					Description: "Validate at plan time the YAML of the pipelines with the Harness API, in addition to the validation against the schemas bundled with the provider. YAML that is not known until apply is not validated. Defaults to `false`. This can also be set using the `HARNESS_VERIFY_YAML` environment variable.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("HARNESS_VERIFY_YAML", false),
+				},
+				"default_org_id": {
+					Description: "Default organization identifier used by scoped resources when `org_id` is not set on the resource. This can also be set using the `HARNESS_DEFAULT_ORG_ID` environment variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("HARNESS_DEFAULT_ORG_ID", nil),
+				},
+				"default_project_id": {
+					Description:  "Default project identifier used by scoped resources when `project_id` is not set on the resource. The default project is only used for resources that also use the default organization. This can also be set using the `HARNESS_DEFAULT_PROJECT_ID` environment variable.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("HARNESS_DEFAULT_PROJECT_ID", nil),
+					RequiredWith: []string{"default_org_id"},
+				},
+				"default_tags": {
+					Description: "Tags added to every resource that supports tags. Tags set on a resource take precedence over the default tags with the same key.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"tags": {
+								Description: "Tags to associate with every resource, in the `key:value` format.",
+								Type:        schema.TypeSet,
+								Optional:    true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						},
+					},
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"harness_platform_template":                        pipeline_template.DataSourceTemplate(),
@@ -228,11 +399,15 @@ func Provider(version string) func() *schema.Provider {
 				"harness_platform_gitops_repo_cert":                gitops_repo_cert.DataSourceGitOpsRepoCert(),
 				"harness_platform_gitops_repo_cred":                gitops_repo_cred.DataSourceGitOpsRepoCred(),
 				"harness_platform_infrastructure":                  cdng_infrastructure.DataSourceInfrastructure(),
+				"harness_platform_import_blocks":                   import_blocks.DataSourceImportBlocks(),
 				"harness_platform_input_set":                       pipeline_input_set.DataSourceInputSet(),
+				"harness_platform_overlay_input_set":               pipeline_input_set.DataSourceOverlayInputSet(),
 				"harness_platform_monitored_service":               monitored_service.DataSourceMonitoredService(),
 				"harness_platform_organization":                    organization.DataSourceOrganization(),
 				"harness_platform_pipeline":                        pipeline.DataSourcePipeline(),
 				"harness_platform_pipeline_list":                   pipeline.DataSourcePipelineList(),
+				"harness_platform_pipeline_executions":             pipeline.DataSourcePipelineExecutions(),
+				"harness_platform_pipeline_input_template":         pipeline.DataSourcePipelineInputTemplate(),
 				"harness_platform_permissions":                     pl_permissions.DataSourcePermissions(),
 				"harness_platform_project":                         project.DataSourceProject(),
 				"harness_platform_project_list":                    project.DataSourceProjectList(),
@@ -372,9 +547,11 @@ func Provider(version string) func() *schema.Provider {
 				"harness_platform_gitops_repo_cred":                gitops_repo_cred.ResourceGitopsRepoCred(),
 				"harness_platform_infrastructure":                  cdng_infrastructure.ResourceInfrastructure(),
 				"harness_platform_input_set":                       pipeline_input_set.ResourceInputSet(),
+				"harness_platform_overlay_input_set":               pipeline_input_set.ResourceOverlayInputSet(),
 				"harness_platform_monitored_service":               monitored_service.ResourceMonitoredService(),
 				"harness_platform_organization":                    organization.ResourceOrganization(),
 				"harness_platform_pipeline":                        pipeline.ResourcePipeline(),
+				"harness_platform_pipeline_execution":              pipeline.ResourcePipelineExecution(),
 				"harness_platform_project":                         project.ResourceProject(),
 				"harness_platform_service":                         cdng_service.ResourceService(),
 				"harness_platform_user":                            pl_user.ResourceUser(),
@@ -462,33 +639,25 @@ func Provider(version string) func() *schema.Provider {
 			},
 		}
 
+		for _, r := range p.ResourcesMap {
+			tf_helpers.SetDefaultTags(r)
+			tf_helpers.SetReferenceValidation(r)
+		}
+		import_blocks.SetUndiscoveredResourceTypes(p.DataSourcesMap["harness_platform_import_blocks"], p.ResourcesMap)
+
 		p.ConfigureContextFunc = configure(version, p)
 
 		return p
 	}
 }
 
-func getHttpClient(logger *logrus.Logger) *retryablehttp.Client {
-	httpClient := retryablehttp.NewClient()
-	httpClient.HTTPClient.Transport = logging.NewTransport(harness.SDKName, logger, cleanhttp.DefaultPooledClient().Transport)
-	httpClient.RetryMax = 10
-	return httpClient
-}
-
-func getOpenApiHttpClient(logger *logrus.Logger) *retryablehttp.Client {
-	httpClient := retryablehttp.NewClient()
-	httpClient.HTTPClient.Transport = openapi_client_logging.NewTransport(harness.SDKName, logger, cleanhttp.DefaultPooledClient().Transport)
-	httpClient.RetryMax = 10
-	return httpClient
-}
-
-func getCDClient(d *schema.ResourceData, version string) *cd.ApiClient {
+func getCDClient(d *schema.ResourceData, version string, transport http.RoundTripper) *cd.ApiClient {
 	cfg := cd.DefaultConfig()
 	cfg.AccountId = d.Get("account_id").(string)
 	cfg.Endpoint = d.Get("endpoint").(string)
 	cfg.APIKey = d.Get("api_key").(string)
 	cfg.UserAgent = fmt.Sprintf("terraform-provider-harness-%s", version)
-	cfg.HTTPClient = getHttpClient(cfg.Logger)
+	cfg.HTTPClient = getHttpClient(d, cfg.Logger, transport)
 	cfg.DebugLogging = logging.IsDebugOrHigher(cfg.Logger)
 
 	client, err := cd.NewClient(cfg)
@@ -500,97 +669,151 @@ func getCDClient(d *schema.ResourceData, version string) *cd.ApiClient {
 	return client
 }
 
-func getPLClient(d *schema.ResourceData, version string) *nextgen.APIClient {
+func getPLClient(d *schema.ResourceData, version string, transport http.RoundTripper) *nextgen.APIClient {
 	cfg := nextgen.NewConfiguration()
 	client := nextgen.NewAPIClient(&nextgen.Configuration{
 		AccountId:    d.Get("account_id").(string),
 		BasePath:     d.Get("endpoint").(string),
 		ApiKey:       d.Get("platform_api_key").(string),
 		UserAgent:    fmt.Sprintf("terraform-provider-harness-platform-%s", version),
-		HTTPClient:   getHttpClient(cfg.Logger),
+		HTTPClient:   getHttpClient(d, cfg.Logger, transport),
 		DebugLogging: logging.IsDebugOrHigher(cfg.Logger),
 	})
 
 	return client
 }
 
-func getDBOpsClient(d *schema.ResourceData, version string) *dbops.APIClient {
+func getDBOpsClient(d *schema.ResourceData, version string, transport http.RoundTripper) *dbops.APIClient {
+	cfg := nextgen.NewConfiguration()
 	client := dbops.NewAPIClient(&dbops.Configuration{
-		AccountId: d.Get("account_id").(string),
-		BasePath:  d.Get("endpoint").(string),
-		ApiKey:    d.Get("platform_api_key").(string),
-		UserAgent: fmt.Sprintf("terraform-provider-harness-platform-%s", version),
+		AccountId:  d.Get("account_id").(string),
+		BasePath:   d.Get("endpoint").(string),
+		ApiKey:     d.Get("platform_api_key").(string),
+		UserAgent:  fmt.Sprintf("terraform-provider-harness-platform-%s", version),
+		HTTPClient: getHttpClient(d, cfg.Logger, transport).StandardClient(),
 	})
 
 	return client
 }
 
-func getClient(d *schema.ResourceData, version string) *openapi_client_nextgen.APIClient {
+func getClient(d *schema.ResourceData, version string, transport http.RoundTripper) *openapi_client_nextgen.APIClient {
 	cfg := openapi_client_nextgen.NewConfiguration()
 	client := openapi_client_nextgen.NewAPIClient(&openapi_client_nextgen.Configuration{
 		AccountId:    d.Get("account_id").(string),
 		BasePath:     d.Get("endpoint").(string),
 		ApiKey:       d.Get("platform_api_key").(string),
 		UserAgent:    fmt.Sprintf("terraform-provider-harness-platform-%s", version),
-		HTTPClient:   getOpenApiHttpClient(cfg.Logger),
+		HTTPClient:   getOpenApiHttpClient(d, cfg.Logger, transport),
 		DebugLogging: openapi_client_logging.IsDebugOrHigher(cfg.Logger),
 	})
 
 	return client
 }
 
-func getCodeClient(d *schema.ResourceData, version string) *code.APIClient {
+func getCodeClient(d *schema.ResourceData, version string, transport http.RoundTripper) *code.APIClient {
 	cfg := code.NewConfiguration()
 	client := code.NewAPIClient(&code.Configuration{
 		AccountId:     d.Get("account_id").(string),
 		BasePath:      d.Get("endpoint").(string) + "/code/api/v1", // todo: this should be fixed in go sdk later
 		ApiKey:        d.Get("platform_api_key").(string),
 		UserAgent:     fmt.Sprintf("terraform-provider-harness-platform-%s", version),
-		HTTPClient:    getOpenApiHttpClient(cfg.Logger),
+		HTTPClient:    getOpenApiHttpClient(d, cfg.Logger, transport),
 		DefaultHeader: map[string]string{"X-Api-Key": d.Get("platform_api_key").(string)}, // todo: this should be fixed in go sdk later
 		DebugLogging:  openapi_client_logging.IsDebugOrHigher(cfg.Logger),
 	})
 	return client
 }
 
-func getChaosClient(d *schema.ResourceData, version string) *chaos.APIClient {
+func getChaosClient(d *schema.ResourceData, version string, transport http.RoundTripper) *chaos.APIClient {
+	cfg := nextgen.NewConfiguration()
 	client := chaos.NewAPIClient(&chaos.Configuration{
 		AccountId:     d.Get("account_id").(string),
 		BasePath:      d.Get("endpoint").(string) + "/chaos/manager/api", // check if this can be taken from sdk
 		ApiKey:        d.Get("platform_api_key").(string),
 		UserAgent:     fmt.Sprintf("terraform-provider-harness-platform-%s", version),
 		DefaultHeader: map[string]string{"X-Api-Key": d.Get("platform_api_key").(string)},
+		HTTPClient:    getHttpClient(d, cfg.Logger, transport).StandardClient(),
 	})
 	return client
 }
 
-func getHarClient(d *schema.ResourceData, version string) *har.APIClient {
+func getHarClient(d *schema.ResourceData, version string, transport http.RoundTripper) *har.APIClient {
 	cfg := har.NewConfiguration()
 	client := har.NewAPIClient(&har.Configuration{
 		AccountId:     d.Get("account_id").(string),
 		BasePath:      d.Get("endpoint").(string) + "/har/api/v1",
 		ApiKey:        d.Get("platform_api_key").(string),
 		UserAgent:     fmt.Sprintf("terraform-provider-harness-platform-%s", version),
-		HTTPClient:    getOpenApiHttpClient(cfg.Logger),
+		HTTPClient:    getOpenApiHttpClient(d, cfg.Logger, transport),
 		DefaultHeader: map[string]string{"X-Api-Key": d.Get("platform_api_key").(string)},
 		DebugLogging:  openapi_client_logging.IsDebugOrHigher(cfg.Logger),
 	})
 	return client
 }
 
+func getDefaultTags(d *schema.ResourceData) map[string]string {
+	if attr, ok := d.GetOk("default_tags.0.tags"); ok {
+		return tf_helpers.ExpandTags(attr.(*schema.Set).List())
+	}
+	return nil
+}
+
 // Setup the client for interacting with the Harness API
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		return &internal.Session{
-			AccountId:   d.Get("account_id").(string),
-			Endpoint:    d.Get("endpoint").(string),
-			CDClient:    getCDClient(d, version),
-			PLClient:    getPLClient(d, version),
-			Client:      getClient(d, version),
-			CodeClient:  getCodeClient(d, version),
-			DBOpsClient: getDBOpsClient(d, version),
-			ChaosClient: getChaosClient(d, version),
-			HARClient:   getHarClient(d, version),
-		}, nil
+		if waitMin, waitMax := d.Get("retry_wait_min").(int), d.Get("retry_wait_max").(int); waitMin > waitMax {
+			return nil, diag.Errorf("retry_wait_min (%d) must be less than or equal to retry_wait_max (%d)", waitMin, waitMax)
+		}
+
+		transport, err := getTransport(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		// The NextGen clients authenticate with the token source when platform_api_key_file or oidc is set,
+		// the FirstGen client keeps using api_key.
+		platformTransport := transport
+		platformTokenSource, err := getPlatformTokenSource(d, transport)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if platformTokenSource != nil {
+			platformTransport = newApiKeyTransport(platformTokenSource, transport)
+		}
+
+		session := &internal.Session{
+			AccountId:        d.Get("account_id").(string),
+			Endpoint:         d.Get("endpoint").(string),
+			DefaultOrgId:     d.Get("default_org_id").(string),
+			DefaultProjectId: d.Get("default_project_id").(string),
+			DefaultTags:      getDefaultTags(d),
+			VerifyReferences: d.Get("verify_references").(bool),
+			VerifyYaml:       d.Get("verify_yaml").(bool),
+			CDClient:         getCDClient(d, version, transport),
+			PLClient:         getPLClient(d, version, platformTransport),
+			Client:           getClient(d, version, platformTransport),
+			CodeClient:       getCodeClient(d, version, platformTransport),
+			DBOpsClient:      getDBOpsClient(d, version, platformTransport),
+			ChaosClient:      getChaosClient(d, version, platformTransport),
+			HARClient:        getHarClient(d, version, platformTransport),
+			PMHTTPClient:     getHttpClient(d, nextgen.NewConfiguration().Logger, platformTransport).StandardClient(),
+		}
+
+		if d.Get("validate_credentials").(bool) {
+			platformApiKey := d.Get("platform_api_key").(string)
+			if platformTokenSource != nil {
+				if platformApiKey, err = platformTokenSource.Token(ctx); err != nil {
+					return nil, diag.FromErr(err)
+				}
+			}
+
+			diags := validateCredentials(ctx, d, session, platformApiKey)
+			if diags.HasError() {
+				return nil, diags
+			}
+			return session, diags
+		}
+
+		return session, nil
 	}
 }
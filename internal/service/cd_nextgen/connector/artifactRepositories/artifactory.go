@@ -64,6 +64,7 @@ func ResourceConnectorArtifactory() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -50,6 +50,7 @@ func ResourceConnectorAzureArtifacts() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -83,6 +83,7 @@ func ResourceConnectorDocker() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -71,6 +71,7 @@ func ResourceConnectorHelm() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
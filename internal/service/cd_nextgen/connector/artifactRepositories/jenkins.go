@@ -93,6 +93,7 @@ func ResourceConnectorJenkins() *schema.Resource {
 		},
 	}
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -23,7 +23,7 @@ func ResourceEnvironment() *schema.Resource {
 		UpdateContext: resourceEnvironmentCreateOrUpdate,
 		DeleteContext: resourceEnvironmentDelete,
 		CreateContext: resourceEnvironmentCreateOrUpdate,
-		Importer:      helpers.MultiLevelResourceImporter,
+		Importer:      helpers.MultiLevelGitResourceImporter,
 
 		Schema: map[string]*schema.Schema{
 			"color": {
@@ -168,6 +168,7 @@ func ResourceEnvironment() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -176,6 +176,7 @@ func ResourceInfrastructure() *schema.Resource {
 		},
 	}
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	// overwrite schema for tags since these are read from the yaml
 	if s, ok := resource.Schema["tags"]; ok {
@@ -21,7 +21,7 @@ func ResourceService() *schema.Resource {
 		UpdateContext: resourceServiceCreateOrUpdate,
 		DeleteContext: resourceServiceDelete,
 		CreateContext: resourceServiceCreateOrUpdate,
-		Importer:      helpers.MultiLevelResourceImporter,
+		Importer:      helpers.MultiLevelGitResourceImporter,
 
 		Schema: map[string]*schema.Schema{
 			"yaml": {
@@ -149,6 +149,7 @@ func ResourceService() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
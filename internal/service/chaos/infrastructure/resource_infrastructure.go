@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/harness/harness-go-sdk/harness/chaos"
 	hh "github.com/harness/harness-go-sdk/harness/helpers"
@@ -22,6 +23,13 @@ func ResourceChaosInfrastructure() *schema.Resource {
 		CreateContext: resourceChaosInfrastructureCreate,
 		Importer:      helpers.MultiLevelResourceImporter,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"org_id": {
 				Description: "Identifier of the organization in which the chaos infrastructure is configured.",
@@ -75,6 +83,7 @@ func ResourceChaosInfrastructure() *schema.Resource {
 	}
 
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -98,11 +107,6 @@ func resourceChaosInfrastructureRead(ctx context.Context, d *schema.ResourceData
 	resp, httpResp, err := c.ChaosSdkApi.GetInfraV2(ctx, identifier, accountIdentifier, orgIdentifier, projectIdentifier, envIdentifier)
 
 	if err != nil {
-		if err.Error() == "404 Not Found" {
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 	readChaosInfrastructure(d, resp)
@@ -28,74 +28,7 @@ func DataSourceInputSet() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"git_details": {
-				Description: "Contains parameters related to creating an Entity for Git Experience.",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"branch_name": {
-							Description: "Name of the branch.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"file_path": {
-							Description: "File path of the Entity in the repository.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"commit_message": {
-							Description: "Commit message used for the merge commit.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"base_branch": {
-							Description: "Name of the default branch (this checks out a new branch titled by branch_name).",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"connector_ref": {
-							Description: "Identifier of the Harness Connector used for CRUD operations on the Entity.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"store_type": {
-							Description: "Specifies whether the Entity is to be stored in Git or not. Possible values: INLINE, REMOTE.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"repo_name": {
-							Description: "Name of the repository.",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"last_object_id": {
-							Description: "Last object identifier (for Github). To be provided only when updating Pipeline.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"last_commit_id": {
-							Description: "Last commit identifier (for Git Repositories other than Github). To be provided only when updating Pipeline.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"parent_entity_connector_ref": {
-							Description: "Connector reference for Parent Entity (Pipeline).",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"parent_entity_repo_name": {
-							Description: "Repository name for Parent Entity (Pipeline).",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-					},
-				},
-			},
+			"git_details": dataSourceGitDetailsSchema(),
 		},
 	}
 
@@ -104,6 +37,78 @@ func DataSourceInputSet() *schema.Resource {
 	return resource
 }
 
+// dataSourceGitDetailsSchema is the git_details block of the input set data sources.
+func dataSourceGitDetailsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Contains parameters related to creating an Entity for Git Experience.",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"branch_name": {
+					Description: "Name of the branch.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"file_path": {
+					Description: "File path of the Entity in the repository.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"commit_message": {
+					Description: "Commit message used for the merge commit.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"base_branch": {
+					Description: "Name of the default branch (this checks out a new branch titled by branch_name).",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"connector_ref": {
+					Description: "Identifier of the Harness Connector used for CRUD operations on the Entity.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"store_type": {
+					Description: "Specifies whether the Entity is to be stored in Git or not. Possible values: INLINE, REMOTE.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"repo_name": {
+					Description: "Name of the repository.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"last_object_id": {
+					Description: "Last object identifier (for Github). To be provided only when updating Pipeline.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"last_commit_id": {
+					Description: "Last commit identifier (for Git Repositories other than Github). To be provided only when updating Pipeline.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"parent_entity_connector_ref": {
+					Description: "Connector reference for Parent Entity (Pipeline).",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"parent_entity_repo_name": {
+					Description: "Repository name for Parent Entity (Pipeline).",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
 func dataSourceInputSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
 
@@ -30,89 +30,13 @@ func ResourceInputSet() *schema.Resource {
 				Required:    true,
 			},
 			"yaml": {
-				Description: "Input Set YAML." + helpers.Descriptions.YamlText.String(),
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-			},
-			"git_details": {
-				Description: "Contains parameters related to creating an Entity for Git Experience.",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				Optional:    true,
-				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"branch_name": {
-							Description: "Name of the branch.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"file_path": {
-							Description: "File path of the Entity in the repository.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"commit_message": {
-							Description: "Commit message used for the merge commit.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"base_branch": {
-							Description: "Name of the default branch (this checks out a new branch titled by branch_name).",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"connector_ref": {
-							Description: "Identifier of the Harness Connector used for CRUD operations on the Entity." + helpers.Descriptions.ConnectorRefText.String(),
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"store_type": {
-							Description:  "Specifies whether the Entity is to be stored in Git or not. Possible values: INLINE, REMOTE.",
-							Type:         schema.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"INLINE", "REMOTE"}, false),
-						},
-						"repo_name": {
-							Description: "Name of the repository.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"last_object_id": {
-							Description: "Last object identifier (for Github). To be provided only when updating Pipeline.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"last_commit_id": {
-							Description: "Last commit identifier (for Git Repositories other than Github). To be provided only when updating Pipeline.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"parent_entity_connector_ref": {
-							Description: "Connector reference for Parent Entity (Pipeline)." + helpers.Descriptions.ConnectorRefText.String(),
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"parent_entity_repo_name": {
-							Description: "Repository name for Parent Entity (Pipeline).",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-					},
-				},
+				Description:      "Input Set YAML." + helpers.Descriptions.YamlText.String(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunction,
 			},
+			"git_details": resourceGitDetailsSchema(),
 			"import_from_git": {
 				Description: "Flag to set if importing from Git",
 				Type:        schema.TypeBool,
@@ -176,10 +100,94 @@ func ResourceInputSet() *schema.Resource {
 		},
 	}
 	helpers.SetProjectLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Project)
+	helpers.SetYamlValidation(resource, "yaml", helpers.YamlKinds.InputSet)
 
 	return resource
 }
 
+// resourceGitDetailsSchema is the git_details block of the input set resources.
+func resourceGitDetailsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Contains parameters related to creating an Entity for Git Experience.",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"branch_name": {
+					Description: "Name of the branch.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"file_path": {
+					Description: "File path of the Entity in the repository.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"commit_message": {
+					Description: "Commit message used for the merge commit.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"base_branch": {
+					Description: "Name of the default branch (this checks out a new branch titled by branch_name).",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"connector_ref": {
+					Description: "Identifier of the Harness Connector used for CRUD operations on the Entity." + helpers.Descriptions.ConnectorRefText.String(),
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"store_type": {
+					Description:  "Specifies whether the Entity is to be stored in Git or not. Possible values: INLINE, REMOTE.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringInSlice([]string{"INLINE", "REMOTE"}, false),
+				},
+				"repo_name": {
+					Description: "Name of the repository.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"last_object_id": {
+					Description: "Last object identifier (for Github). To be provided only when updating Pipeline.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"last_commit_id": {
+					Description: "Last commit identifier (for Git Repositories other than Github). To be provided only when updating Pipeline.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"parent_entity_connector_ref": {
+					Description: "Connector reference for Parent Entity (Pipeline)." + helpers.Descriptions.ConnectorRefText.String(),
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+				"parent_entity_repo_name": {
+					Description: "Repository name for Parent Entity (Pipeline).",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
 func resourceInputSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
 
@@ -385,82 +393,92 @@ func resourceInputSetDelete(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func buildCreateInputSet(d *schema.ResourceData) nextgen.InputSetCreateRequestBody {
-	inputSet := nextgen.InputSetCreateRequestBody{
+	return nextgen.InputSetCreateRequestBody{
 		Identifier:   d.Get("identifier").(string),
 		Name:         d.Get("name").(string),
 		Description:  d.Get("description").(string),
 		Tags:         helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
 		InputSetYaml: d.Get("yaml").(string),
+		GitDetails:   buildGitCreateDetails(d),
 	}
+}
 
-	if attr, ok := d.GetOk("git_details"); ok {
-		config := attr.([]interface{})[0].(map[string]interface{})
-		inputSet.GitDetails = &nextgen.GitCreateDetails{}
-		if attr, ok := config["branch_name"]; ok {
-			inputSet.GitDetails.BranchName = attr.(string)
-		}
-		if attr, ok := config["file_path"]; ok {
-			inputSet.GitDetails.FilePath = attr.(string)
-		}
-		if attr, ok := config["commit_message"]; ok {
-			inputSet.GitDetails.CommitMessage = attr.(string)
-		}
-		if attr, ok := config["base_branch"]; ok {
-			inputSet.GitDetails.BaseBranch = attr.(string)
-		}
-		if attr, ok := config["connector_ref"]; ok {
-			inputSet.GitDetails.ConnectorRef = attr.(string)
-		}
-		if attr, ok := config["store_type"]; ok {
-			inputSet.GitDetails.StoreType = attr.(string)
-		}
-		if attr, ok := config["repo_name"]; ok {
-			inputSet.GitDetails.RepoName = attr.(string)
-		}
+func buildGitCreateDetails(d *schema.ResourceData) *nextgen.GitCreateDetails {
+	attr, ok := d.GetOk("git_details")
+	if !ok {
+		return nil
+	}
+	config := attr.([]interface{})[0].(map[string]interface{})
+	gitDetails := &nextgen.GitCreateDetails{}
+	if attr, ok := config["branch_name"]; ok {
+		gitDetails.BranchName = attr.(string)
 	}
-	return inputSet
+	if attr, ok := config["file_path"]; ok {
+		gitDetails.FilePath = attr.(string)
+	}
+	if attr, ok := config["commit_message"]; ok {
+		gitDetails.CommitMessage = attr.(string)
+	}
+	if attr, ok := config["base_branch"]; ok {
+		gitDetails.BaseBranch = attr.(string)
+	}
+	if attr, ok := config["connector_ref"]; ok {
+		gitDetails.ConnectorRef = attr.(string)
+	}
+	if attr, ok := config["store_type"]; ok {
+		gitDetails.StoreType = attr.(string)
+	}
+	if attr, ok := config["repo_name"]; ok {
+		gitDetails.RepoName = attr.(string)
+	}
+	return gitDetails
 }
 
 func buildUpdateInputSet(d *schema.ResourceData) nextgen.InputSetUpdateRequestBody {
-	inputSet := nextgen.InputSetUpdateRequestBody{
+	return nextgen.InputSetUpdateRequestBody{
 		Identifier:   d.Get("identifier").(string),
 		Name:         d.Get("name").(string),
 		Description:  d.Get("description").(string),
 		Tags:         helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
 		InputSetYaml: d.Get("yaml").(string),
+		GitDetails:   buildGitUpdateDetails(d),
 	}
+}
 
-	if attr, ok := d.GetOk("git_details"); ok {
-		configs := attr.([]interface{})
-		if len(configs) > 0 {
-			config := configs[0].(map[string]interface{})
-
-			inputSet.GitDetails = &nextgen.InputSetGitUpdateDetails{}
+func buildGitUpdateDetails(d *schema.ResourceData) *nextgen.InputSetGitUpdateDetails {
+	attr, ok := d.GetOk("git_details")
+	if !ok {
+		return nil
+	}
+	configs := attr.([]interface{})
+	if len(configs) == 0 {
+		return nil
+	}
+	config := configs[0].(map[string]interface{})
 
-			if attr, ok := config["branch_name"]; ok {
-				inputSet.GitDetails.BranchName = attr.(string)
-			}
-			if attr, ok := config["commit_message"]; ok {
-				inputSet.GitDetails.CommitMessage = attr.(string)
-			}
-			if attr, ok := config["base_branch"]; ok {
-				inputSet.GitDetails.BaseBranch = attr.(string)
-			}
-			if attr, ok := config["last_object_id"]; ok {
-				inputSet.GitDetails.LastObjectId = attr.(string)
-			}
-			if attr, ok := config["last_commit_id"]; ok {
-				inputSet.GitDetails.LastCommitId = attr.(string)
-			}
-			if attr, ok := config["parent_entity_connector_ref"]; ok {
-				inputSet.GitDetails.ParentEntityConnectorRef = attr.(string)
-			}
-			if attr, ok := config["parent_entity_repo_name"]; ok {
-				inputSet.GitDetails.ParentEntityRepoName = attr.(string)
-			}
-		}
+	gitDetails := &nextgen.InputSetGitUpdateDetails{}
+	if attr, ok := config["branch_name"]; ok {
+		gitDetails.BranchName = attr.(string)
+	}
+	if attr, ok := config["commit_message"]; ok {
+		gitDetails.CommitMessage = attr.(string)
+	}
+	if attr, ok := config["base_branch"]; ok {
+		gitDetails.BaseBranch = attr.(string)
+	}
+	if attr, ok := config["last_object_id"]; ok {
+		gitDetails.LastObjectId = attr.(string)
+	}
+	if attr, ok := config["last_commit_id"]; ok {
+		gitDetails.LastCommitId = attr.(string)
+	}
+	if attr, ok := config["parent_entity_connector_ref"]; ok {
+		gitDetails.ParentEntityConnectorRef = attr.(string)
+	}
+	if attr, ok := config["parent_entity_repo_name"]; ok {
+		gitDetails.ParentEntityRepoName = attr.(string)
 	}
-	return inputSet
+	return gitDetails
 }
 
 func readInputSet(d *schema.ResourceData, inputSet *nextgen.InputSetResponseBody, pipelineId string, store_type optional.String, base_branch optional.String, commit_message optional.String, connector_ref optional.String) {
@@ -0,0 +1,73 @@
+package input_set
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceOverlayInputSet() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving a Harness overlay input set.",
+
+		ReadContext: dataSourceOverlayInputSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Description: "Identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"input_set_references": {
+				Description: "Identifiers of the input sets combined by the overlay input set, in the order they are applied.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"yaml": {
+				Description: "Overlay input set YAML.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"git_details": dataSourceGitDetailsSchema(),
+		},
+	}
+
+	helpers.SetProjectLevelDataSourceSchemaIdentifierRequired(resource.Schema)
+
+	return resource
+}
+
+func dataSourceOverlayInputSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	pipelineId := d.Get("pipeline_id").(string)
+
+	resp, httpResp, err := c.InputSetsApi.GetInputSet(ctx,
+		d.Get("org_id").(string),
+		d.Get("project_id").(string),
+		d.Get("identifier").(string),
+		pipelineId,
+		&nextgen.InputSetsApiGetInputSetOpts{
+			HarnessAccount:           optional.NewString(c.AccountId),
+			BranchName:               helpers.BuildField(d, "git_details.0.branch_name"),
+			ParentEntityConnectorRef: helpers.BuildField(d, "git_details.0.parent_entity_connector_ref"),
+			ParentEntityRepoName:     helpers.BuildField(d, "git_details.0.parent_entity_repo_name"),
+		},
+	)
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return readOverlayInputSet(d, &resp, pipelineId,
+		helpers.BuildField(d, "git_details.0.store_type"),
+		helpers.BuildField(d, "git_details.0.base_branch"),
+		helpers.BuildField(d, "git_details.0.commit_message"),
+		helpers.BuildField(d, "git_details.0.connector_ref"))
+}
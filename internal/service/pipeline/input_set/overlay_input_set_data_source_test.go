@@ -0,0 +1,45 @@
+package input_set_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOverlayInputSet(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+	resourceName := "data.harness_platform_overlay_input_set.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOverlayInputSet(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "identifier", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_id", id),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.0", "second"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.1", "first"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOverlayInputSet(id string, name string) string {
+	return testAccResourceOverlayInputSet(id, name, `"second", "first"`) + `
+		data "harness_platform_overlay_input_set" "test" {
+			identifier = harness_platform_overlay_input_set.test.id
+			org_id = harness_platform_overlay_input_set.test.org_id
+			project_id = harness_platform_overlay_input_set.test.project_id
+			pipeline_id = harness_platform_overlay_input_set.test.pipeline_id
+		}
+	`
+}
@@ -0,0 +1,254 @@
+package input_set
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// overlayInputSetYaml is the YAML of an overlay input set, which is generated from the arguments of the resource.
+type overlayInputSetYaml struct {
+	OverlayInputSet *overlayInputSet `yaml:"overlayInputSet"`
+}
+
+type overlayInputSet struct {
+	Name               string            `yaml:"name"`
+	Identifier         string            `yaml:"identifier"`
+	OrgIdentifier      string            `yaml:"orgIdentifier"`
+	ProjectIdentifier  string            `yaml:"projectIdentifier"`
+	PipelineIdentifier string            `yaml:"pipelineIdentifier"`
+	Description        string            `yaml:"description,omitempty"`
+	Tags               map[string]string `yaml:"tags,omitempty"`
+	InputSetReferences []string          `yaml:"inputSetReferences"`
+}
+
+func ResourceOverlayInputSet() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for creating a Harness overlay input set, which combines input sets of a pipeline. The input sets are applied in order, the values of an input set overriding the values of the previous ones.",
+
+		ReadContext:   resourceOverlayInputSetRead,
+		UpdateContext: resourceOverlayInputSetCreateOrUpdate,
+		CreateContext: resourceOverlayInputSetCreateOrUpdate,
+		DeleteContext: resourceInputSetDelete,
+		Importer:      helpers.PipelineResourceImporter,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Description: "Identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"input_set_references": {
+				Description: "Identifiers of the input sets combined by the overlay input set, in the order they are applied. The input sets must belong to the same pipeline and can't be overlay input sets.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: helpers.ValidateIdentifier,
+				},
+			},
+			"yaml": {
+				Description: "Overlay input set YAML, generated from the arguments of the resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"git_details": resourceGitDetailsSchema(),
+		},
+	}
+	helpers.SetProjectLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Project)
+
+	return resource
+}
+
+func resourceOverlayInputSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	id := d.Get("identifier").(string)
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+
+	resp, httpResp, err := c.InputSetsApi.GetInputSet(ctx, orgId, projectId, id, pipelineId, &nextgen.InputSetsApiGetInputSetOpts{
+		HarnessAccount:           optional.NewString(c.AccountId),
+		BranchName:               helpers.BuildField(d, "git_details.0.branch_name"),
+		ParentEntityConnectorRef: helpers.BuildField(d, "git_details.0.parent_entity_connector_ref"),
+		ParentEntityRepoName:     helpers.BuildField(d, "git_details.0.parent_entity_repo_name"),
+	})
+
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return readOverlayInputSet(d, &resp, pipelineId,
+		helpers.BuildField(d, "git_details.0.store_type"),
+		helpers.BuildField(d, "git_details.0.base_branch"),
+		helpers.BuildField(d, "git_details.0.commit_message"),
+		helpers.BuildField(d, "git_details.0.connector_ref"))
+}
+
+func resourceOverlayInputSetCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetClientWithContext(ctx)
+
+	var err error
+	var resp nextgen.InputSetResponseBody
+	var httpResp *http.Response
+
+	id := d.Id()
+	orgIdentifier := d.Get("org_id").(string)
+	projectIdentifier := d.Get("project_id").(string)
+	pipelineIdentifier := d.Get("pipeline_id").(string)
+
+	if diags := validateInputSetReferences(ctx, c, d); diags.HasError() {
+		return diags
+	}
+
+	overlayYaml, err := buildOverlayInputSetYaml(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var store_type optional.String
+	var base_branch optional.String
+	var commit_message optional.String
+	var connector_ref optional.String
+
+	if id == "" {
+		inputSet := nextgen.InputSetCreateRequestBody{
+			Identifier:   d.Get("identifier").(string),
+			Name:         d.Get("name").(string),
+			Description:  d.Get("description").(string),
+			Tags:         helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
+			InputSetYaml: overlayYaml,
+			GitDetails:   buildGitCreateDetails(d),
+		}
+		if inputSet.GitDetails != nil {
+			base_branch = optional.NewString(inputSet.GitDetails.BaseBranch)
+			store_type = optional.NewString(inputSet.GitDetails.StoreType)
+			commit_message = optional.NewString(inputSet.GitDetails.CommitMessage)
+			connector_ref = optional.NewString(inputSet.GitDetails.ConnectorRef)
+		}
+
+		resp, httpResp, err = c.InputSetsApi.CreateInputSet(ctx, inputSet, pipelineIdentifier, orgIdentifier, projectIdentifier, &nextgen.InputSetsApiCreateInputSetOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	} else {
+		inputSet := nextgen.InputSetUpdateRequestBody{
+			Identifier:   d.Get("identifier").(string),
+			Name:         d.Get("name").(string),
+			Description:  d.Get("description").(string),
+			Tags:         helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
+			InputSetYaml: overlayYaml,
+			GitDetails:   buildGitUpdateDetails(d),
+		}
+		if inputSet.GitDetails != nil {
+			base_branch = optional.NewString(inputSet.GitDetails.BaseBranch)
+			commit_message = optional.NewString(inputSet.GitDetails.CommitMessage)
+		}
+		store_type = helpers.BuildField(d, "git_details.0.store_type")
+		connector_ref = helpers.BuildField(d, "git_details.0.connector_ref")
+
+		resp, httpResp, err = c.InputSetsApi.UpdateInputSet(ctx, inputSet, pipelineIdentifier, orgIdentifier, projectIdentifier, id, &nextgen.InputSetsApiUpdateInputSetOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+		})
+	}
+
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	return readOverlayInputSet(d, &resp, pipelineIdentifier, store_type, base_branch, commit_message, connector_ref)
+}
+
+// validateInputSetReferences checks that the referenced input sets exist in the pipeline of the overlay input set
+// and are not overlay input sets themselves, so that a wrong reference is reported with the name of the argument.
+func validateInputSetReferences(ctx context.Context, c *nextgen.APIClient, d *schema.ResourceData) diag.Diagnostics {
+	orgId := d.Get("org_id").(string)
+	projectId := d.Get("project_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+
+	var diags diag.Diagnostics
+	for i, ref := range d.Get("input_set_references").([]interface{}) {
+		ref := ref.(string)
+		resp, httpResp, err := c.InputSetsApi.GetInputSet(ctx, orgId, projectId, ref, pipelineId, &nextgen.InputSetsApiGetInputSetOpts{
+			HarnessAccount:           optional.NewString(c.AccountId),
+			BranchName:               helpers.BuildField(d, "git_details.0.branch_name"),
+			ParentEntityConnectorRef: helpers.BuildField(d, "git_details.0.parent_entity_connector_ref"),
+			ParentEntityRepoName:     helpers.BuildField(d, "git_details.0.parent_entity_repo_name"),
+		})
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			diags = append(diags, diag.Errorf("input_set_references.%d: the input set %q does not exist in the pipeline %s", i, ref, pipelineId)...)
+			continue
+		}
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+
+		var doc overlayInputSetYaml
+		if err := yaml.Unmarshal([]byte(resp.InputSetYaml), &doc); err == nil && doc.OverlayInputSet != nil {
+			diags = append(diags, diag.Errorf("input_set_references.%d: %q is an overlay input set, which can't be referenced by an overlay input set", i, ref)...)
+		}
+	}
+	return diags
+}
+
+func buildOverlayInputSetYaml(d *schema.ResourceData) (string, error) {
+	doc := overlayInputSetYaml{
+		OverlayInputSet: &overlayInputSet{
+			Name:               d.Get("name").(string),
+			Identifier:         d.Get("identifier").(string),
+			OrgIdentifier:      d.Get("org_id").(string),
+			ProjectIdentifier:  d.Get("project_id").(string),
+			PipelineIdentifier: d.Get("pipeline_id").(string),
+			Description:        d.Get("description").(string),
+			Tags:               helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
+			InputSetReferences: helpers.ExpandField(d.Get("input_set_references").([]interface{})),
+		},
+	}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("error generating the overlay input set YAML: %w", err)
+	}
+	return string(data), nil
+}
+
+func readOverlayInputSet(d *schema.ResourceData, inputSet *nextgen.InputSetResponseBody, pipelineId string, store_type optional.String, base_branch optional.String, commit_message optional.String, connector_ref optional.String) diag.Diagnostics {
+	var doc overlayInputSetYaml
+	if err := yaml.Unmarshal([]byte(inputSet.InputSetYaml), &doc); err != nil {
+		return diag.Errorf("invalid overlay input set YAML: %s", err)
+	}
+	if doc.OverlayInputSet == nil {
+		return diag.Errorf("the input set %s of the pipeline %s is not an overlay input set, use the harness_platform_input_set resource instead", inputSet.Identifier, pipelineId)
+	}
+
+	d.SetId(inputSet.Identifier)
+	d.Set("identifier", inputSet.Identifier)
+	d.Set("name", inputSet.Name)
+	d.Set("description", inputSet.Description)
+	d.Set("tags", helpers.FlattenTags(inputSet.Tags))
+	d.Set("org_id", inputSet.Org)
+	d.Set("project_id", inputSet.Project)
+	d.Set("pipeline_id", pipelineId)
+	d.Set("input_set_references", doc.OverlayInputSet.InputSetReferences)
+	d.Set("yaml", inputSet.InputSetYaml)
+	if inputSet.GitDetails != nil {
+		d.Set("git_details", []interface{}{readGitDetails(inputSet, store_type, base_branch, commit_message, connector_ref)})
+	}
+	return nil
+}
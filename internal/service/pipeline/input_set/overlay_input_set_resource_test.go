@@ -0,0 +1,257 @@
+package input_set_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/input_set"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/pipeline"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceOverlayInputSet(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	server.SeedProject(t, "org", "project")
+	for _, id := range []string{"pipeline", "other"} {
+		server.Create(t, pipeline.ResourcePipeline(), map[string]interface{}{
+			"identifier": id,
+			"name":       id,
+			"org_id":     "org",
+			"project_id": "project",
+			"yaml":       fmt.Sprintf("pipeline:\n  name: %[1]s\n  identifier: %[1]s\n", id),
+		})
+	}
+	inputSet := func(pipelineId string, id string) {
+		server.Create(t, input_set.ResourceInputSet(), map[string]interface{}{
+			"identifier":  id,
+			"name":        id,
+			"org_id":      "org",
+			"project_id":  "project",
+			"pipeline_id": pipelineId,
+			"yaml":        fmt.Sprintf("inputSet:\n  name: %[1]s\n  identifier: %[1]s\n  pipeline:\n    identifier: %[2]s\n", id, pipelineId),
+		})
+	}
+	inputSet("pipeline", "first")
+	inputSet("pipeline", "second")
+	inputSet("other", "third")
+
+	r := input_set.ResourceOverlayInputSet()
+	overlay := func(id string, refs ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"identifier":           id,
+			"name":                 id,
+			"org_id":               "org",
+			"project_id":           "project",
+			"pipeline_id":          "pipeline",
+			"tags":                 []interface{}{"foo:bar"},
+			"input_set_references": refs,
+		}
+	}
+
+	d := server.Create(t, r, overlay("overlay", "second", "first"))
+	assert.Equal(t, "overlay", d.Id())
+	assert.True(t, server.Exists("input_set", "org", "project", "pipeline/overlay"))
+	assert.Equal(t, []interface{}{"second", "first"}, d.Get("input_set_references"))
+	assert.Equal(t, `overlayInputSet:
+    name: overlay
+    identifier: overlay
+    orgIdentifier: org
+    projectIdentifier: project
+    pipelineIdentifier: pipeline
+    tags:
+        foo: bar
+    inputSetReferences:
+        - second
+        - first
+`, d.Get("yaml"))
+
+	t.Run("reorders the references", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, overlay("overlay", "first", "second"))
+		d.SetId("overlay")
+		diags := r.UpdateContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+
+		d = schema.TestResourceDataRaw(t, r.Schema, overlay("overlay"))
+		d.SetId("overlay")
+		diags = r.ReadContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []interface{}{"first", "second"}, d.Get("input_set_references"))
+	})
+
+	t.Run("rejects the input sets of other pipelines", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, overlay("invalid", "first", "third", "missing"))
+		diags := r.CreateContext(ctx, d, session)
+		assert.Equal(t, []string{
+			`input_set_references.1: the input set "third" does not exist in the pipeline pipeline`,
+			`input_set_references.2: the input set "missing" does not exist in the pipeline pipeline`,
+		}, summaries(diags))
+		assert.False(t, server.Exists("input_set", "org", "project", "pipeline/invalid"))
+	})
+
+	t.Run("rejects overlay input sets", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, overlay("nested", "overlay"))
+		diags := r.CreateContext(ctx, d, session)
+		assert.Equal(t, []string{
+			`input_set_references.0: "overlay" is an overlay input set, which can't be referenced by an overlay input set`,
+		}, summaries(diags))
+	})
+
+	t.Run("does not read input sets", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, overlay("first"))
+		d.SetId("first")
+		diags := r.ReadContext(ctx, d, session)
+		assert.Equal(t, []string{
+			"the input set first of the pipeline pipeline is not an overlay input set, use the harness_platform_input_set resource instead",
+		}, summaries(diags))
+	})
+
+	t.Run("is removed from the state once deleted", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, overlay("overlay"))
+		d.SetId("overlay")
+		diags := r.DeleteContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		assert.False(t, server.Exists("input_set", "org", "project", "pipeline/overlay"))
+
+		diags = r.ReadContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		assert.Empty(t, d.Id())
+	})
+}
+
+func summaries(diags diag.Diagnostics) []string {
+	var s []string
+	for _, d := range diags {
+		s = append(s, d.Summary)
+	}
+	return s
+}
+
+func TestAccResourceOverlayInputSet(t *testing.T) {
+	name := t.Name()
+	id := fmt.Sprintf("%s_%s", name, utils.RandStringBytes(5))
+	resourceName := "harness_platform_overlay_input_set.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccInputSetDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOverlayInputSet(id, name, `"first", "second"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_id", id),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.0", "first"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.1", "second"),
+				),
+			},
+			{
+				Config: testAccResourceOverlayInputSet(id, name, `"second", "first"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.0", "second"),
+					resource.TestCheckResourceAttr(resourceName, "input_set_references.1", "first"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.PipelineResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccResourceOverlayInputSet(id string, name string, references string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			org_id = harness_platform_organization.test.id
+		}
+
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[2]s"
+			yaml = <<-EOT
+				pipeline:
+				  identifier: %[1]s
+				  name: %[2]s
+				  projectIdentifier: ${harness_platform_project.test.id}
+				  orgIdentifier: ${harness_platform_project.test.org_id}
+				  stages:
+				    - stage:
+				        name: approval
+				        identifier: approval
+				        type: Approval
+				        spec:
+				          execution:
+				            steps:
+				              - step:
+				                  name: wait
+				                  identifier: wait
+				                  type: Wait
+				                  spec:
+				                    duration: 1m
+				  variables:
+				    - name: key
+				      type: String
+				      value: <+input>
+			EOT
+		}
+
+		resource "harness_platform_input_set" "test" {
+			for_each = toset(["first", "second"])
+			identifier = each.key
+			name = each.key
+			org_id = harness_platform_organization.test.id
+			project_id = harness_platform_project.test.id
+			pipeline_id = harness_platform_pipeline.test.id
+			yaml = <<-EOT
+				inputSet:
+				  identifier: ${each.key}
+				  name: ${each.key}
+				  orgIdentifier: ${harness_platform_organization.test.id}
+				  projectIdentifier: ${harness_platform_project.test.id}
+				  pipeline:
+				    identifier: ${harness_platform_pipeline.test.id}
+				    variables:
+				      - name: key
+				        type: String
+				        value: ${each.key}
+			EOT
+		}
+
+		resource "harness_platform_overlay_input_set" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			tags = ["foo:bar"]
+			org_id = harness_platform_organization.test.id
+			project_id = harness_platform_project.test.id
+			pipeline_id = harness_platform_pipeline.test.id
+			input_set_references = [%[3]s]
+
+			depends_on = [harness_platform_input_set.test]
+		}
+	`, id, name, references)
+}
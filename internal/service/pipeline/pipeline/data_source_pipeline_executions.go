@@ -0,0 +1,263 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// executionsPageSize is the number of executions requested per page while listing the executions.
+const executionsPageSize = 100
+
+// pipelineExecutionFilterProperties is the body of the request listing the executions, which filters them. The
+// API client has no model for it.
+type pipelineExecutionFilterProperties struct {
+	FilterType   string                      `json:"filterType"`
+	Status       []string                    `json:"status,omitempty"`
+	TimeRange    *pipelineExecutionTimeRange `json:"timeRange,omitempty"`
+	TriggerTypes []string                    `json:"triggerTypes,omitempty"`
+}
+
+// pipelineExecutionTimeRange is the time range of the filter properties. Unlike nextgen.TimeRange, it always
+// sends both bounds, which the API requires.
+type pipelineExecutionTimeRange struct {
+	StartTime int64 `json:"startTime"`
+	EndTime   int64 `json:"endTime"`
+}
+
+func DataSourcePipelineExecutions() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving the executions of a Harness pipeline, the most recent first.",
+
+		ReadContext: dataSourcePipelineExecutionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id":     helpers.GetOrgIdSchema(helpers.SchemaFlagTypes.Required),
+			"project_id": helpers.GetProjectIdSchema(helpers.SchemaFlagTypes.Required),
+			"pipeline_id": {
+				Description: "Identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"module_type": {
+				Description: "Module of the executions, such as `cd` or `ci`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description: "Statuses of the executions to return, such as `Success`, `Failed` or `Running`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"branch": {
+				Description: "Branch of the executions to return, for pipelines stored in Git.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"start_time": {
+				Description: "Only return the executions started at or after this time, in milliseconds. When `end_time` is not set, the executions started up to now are returned.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"end_time": {
+				Description: "Only return the executions started at or before this time, in milliseconds. When `start_time` is not set, the executions started since the epoch are returned.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"trigger_types": {
+				Description: "Types of trigger of the executions to return. Valid values are `MANUAL`, `WEBHOOK`, `WEBHOOK_CUSTOM`, `SCHEDULER_CRON`, `ARTIFACT` and `MANIFEST`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"MANUAL", "WEBHOOK", "WEBHOOK_CUSTOM", "SCHEDULER_CRON", "ARTIFACT", "MANIFEST"}, false),
+				},
+			},
+			"limit": {
+				Description:  "Maximum number of executions to return. All the executions matching the filters are returned when not set.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"executions": {
+				Description: "Executions of the pipeline, the most recent first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"execution_id": {
+							Description: "Identifier of the execution.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"run_sequence": {
+							Description: "Sequence number of the execution in the pipeline.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Status of the execution.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"start_ts": {
+							Description: "Time at which the execution started, in milliseconds.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"end_ts": {
+							Description: "Time at which the execution ended, in milliseconds. It is 0 while the execution is running.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"branch": {
+							Description: "Branch of the pipeline, for pipelines stored in Git.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"trigger_type": {
+							Description: "Type of trigger of the execution.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"triggered_by": {
+							Description: "Identifier of the user or trigger that started the execution.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"triggered_by_email": {
+							Description: "Email of the user that started the execution.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"stages": {
+							Description: "Stages of the execution, in the order of the pipeline.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"identifier": {
+										Description: "Identifier of the stage.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"name": {
+										Description: "Name of the stage.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"status": {
+										Description: "Status of the stage.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourcePipelineExecutionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	org_id := d.Get("org_id").(string)
+	project_id := d.Get("project_id").(string)
+	pipeline_id := d.Get("pipeline_id").(string)
+	limit := d.Get("limit").(int)
+
+	filter := pipelineExecutionFilterProperties{
+		FilterType:   "PipelineExecution",
+		Status:       helpers.ExpandField(d.Get("status").(*schema.Set).List()),
+		TriggerTypes: helpers.ExpandField(d.Get("trigger_types").(*schema.Set).List()),
+	}
+	start_time, hasStart := d.GetOk("start_time")
+	end_time, hasEnd := d.GetOk("end_time")
+	if hasStart || hasEnd {
+		filter.TimeRange = &pipelineExecutionTimeRange{StartTime: int64(start_time.(int)), EndTime: int64(end_time.(int))}
+		if !hasEnd {
+			filter.TimeRange.EndTime = time.Now().UnixMilli()
+		}
+	}
+
+	opts := &nextgen.ExecutionDetailsApiGetListOfExecutionsOpts{
+		Body:               optional.NewInterface(filter),
+		PipelineIdentifier: optional.NewString(pipeline_id),
+		Size:               optional.NewInt32(executionsPageSize),
+		Sort:               optional.NewInterface("startTs,DESC"),
+		Module:             helpers.BuildField(d, "module_type"),
+		Branch:             helpers.BuildField(d, "branch"),
+	}
+
+	executions := []interface{}{}
+	for page := int32(0); ; page++ {
+		opts.Page = optional.NewInt32(page)
+		resp, httpResp, err := c.ExecutionDetailsApi.GetListOfExecutions(ctx, c.AccountId, org_id, project_id, opts)
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		if resp.Data == nil {
+			break
+		}
+
+		for i := range resp.Data.Content {
+			executions = append(executions, flattenPipelineExecutionSummary(&resp.Data.Content[i]))
+			if limit > 0 && len(executions) == limit {
+				break
+			}
+		}
+		if (limit > 0 && len(executions) == limit) || len(resp.Data.Content) == 0 || resp.Data.Last || page+1 >= resp.Data.TotalPages {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", org_id, project_id, pipeline_id))
+	d.Set("executions", executions)
+
+	return nil
+}
+
+func flattenPipelineExecutionSummary(summary *nextgen.PipelineExecutionSummary) map[string]interface{} {
+	stages := []interface{}{}
+	for _, node := range getExecutionStages(summary) {
+		stages = append(stages, map[string]interface{}{
+			"identifier": node.NodeIdentifier,
+			"name":       node.Name,
+			"status":     node.Status,
+		})
+	}
+
+	execution := map[string]interface{}{
+		"execution_id": summary.PlanExecutionId,
+		"run_sequence": int(summary.RunSequence),
+		"status":       summary.Status,
+		"start_ts":     summary.StartTs,
+		"end_ts":       summary.EndTs,
+		"stages":       stages,
+	}
+	if summary.GitDetails != nil {
+		execution["branch"] = summary.GitDetails.Branch
+	}
+	if info := summary.ExecutionTriggerInfo; info != nil {
+		execution["trigger_type"] = info.TriggerType
+		if info.TriggeredBy != nil {
+			execution["triggered_by"] = info.TriggeredBy.Identifier
+			execution["triggered_by_email"] = info.TriggeredBy.ExtraInfo["email"]
+		}
+	}
+	return execution
+}
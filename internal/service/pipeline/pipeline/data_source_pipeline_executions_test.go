@@ -0,0 +1,140 @@
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/pipeline"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourcePipelineExecutions(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	server.SeedProject(t, "org", "project")
+	server.Create(t, pipeline.ResourcePipeline(), map[string]interface{}{
+		"identifier": "test",
+		"name":       "Test",
+		"org_id":     "org",
+		"project_id": "project",
+		"yaml":       executionPipelineYaml,
+	})
+
+	// More executions than fit in a page, the last one failing.
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+	execute := func(branch string) {
+		opts := &nextgen.ExecuteApiPostPipelineExecuteWithInputSetYamlOpts{}
+		if branch != "" {
+			opts.Branch = optional.NewString(branch)
+		}
+		_, _, err := c.ExecuteApi.PostPipelineExecuteWithInputSetYaml(ctx, c.AccountId, "org", "project", "cd", "test", opts)
+		require.NoError(t, err)
+	}
+	for i := 0; i < 120; i++ {
+		execute("")
+	}
+	server.SetExecutionStatuses("Failed")
+	execute("feature")
+
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		r := pipeline.DataSourcePipelineExecutions()
+		raw["org_id"] = "org"
+		raw["project_id"] = "project"
+		raw["pipeline_id"] = "test"
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		diags := r.ReadContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		return d
+	}
+
+	t.Run("lists all the executions", func(t *testing.T) {
+		d := read(map[string]interface{}{})
+
+		assert.Equal(t, "org/project/test", d.Id())
+		assert.Equal(t, 121, d.Get("executions.#"))
+		assert.Equal(t, "execution121", d.Get("executions.0.execution_id"))
+		assert.Equal(t, "Failed", d.Get("executions.0.status"))
+		assert.Equal(t, "feature", d.Get("executions.0.branch"))
+		assert.Equal(t, "MANUAL", d.Get("executions.0.trigger_type"))
+		assert.Equal(t, "fake_user", d.Get("executions.0.triggered_by"))
+		assert.Equal(t, "fake_user@harness.io", d.Get("executions.0.triggered_by_email"))
+		assert.NotZero(t, d.Get("executions.0.end_ts"))
+		assert.Equal(t, "stage", d.Get("executions.0.stages.0.identifier"))
+		assert.Equal(t, "Failed", d.Get("executions.0.stages.0.status"))
+		assert.Equal(t, "execution1", d.Get("executions.120.execution_id"))
+	})
+
+	t.Run("limits the executions", func(t *testing.T) {
+		d := read(map[string]interface{}{"limit": 3})
+
+		assert.Equal(t, 3, d.Get("executions.#"))
+		assert.Equal(t, "execution119", d.Get("executions.2.execution_id"))
+	})
+
+	t.Run("filters the executions", func(t *testing.T) {
+		d := read(map[string]interface{}{"status": []interface{}{"Failed"}})
+		assert.Equal(t, 1, d.Get("executions.#"))
+
+		d = read(map[string]interface{}{"branch": "feature"})
+		assert.Equal(t, 1, d.Get("executions.#"))
+
+		d = read(map[string]interface{}{"trigger_types": []interface{}{"WEBHOOK"}})
+		assert.Equal(t, 0, d.Get("executions.#"))
+
+		d = read(map[string]interface{}{"start_time": int(time.Now().Add(time.Hour).UnixMilli())})
+		assert.Equal(t, 0, d.Get("executions.#"))
+
+		d = read(map[string]interface{}{"start_time": int(time.Now().Add(-time.Hour).UnixMilli())})
+		assert.Equal(t, 121, d.Get("executions.#"))
+
+		d = read(map[string]interface{}{"end_time": int(time.Now().UnixMilli()), "trigger_types": []interface{}{"MANUAL"}})
+		assert.Equal(t, 121, d.Get("executions.#"))
+	})
+}
+
+func TestAccDataSourcePipelineExecutions(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	resourceName := "data.harness_platform_pipeline_executions.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePipelineExecutions(id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "executions.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "executions.0.execution_id", "harness_platform_pipeline_execution.test", "execution_id"),
+					resource.TestCheckResourceAttr(resourceName, "executions.0.status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "executions.0.trigger_type", "MANUAL"),
+					resource.TestCheckResourceAttr(resourceName, "executions.0.stages.0.identifier", "echo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePipelineExecutions(id string) string {
+	return fmt.Sprintf(`
+		%s
+
+		data "harness_platform_pipeline_executions" "test" {
+			org_id = harness_platform_pipeline_execution.test.org_id
+			project_id = harness_platform_pipeline_execution.test.project_id
+			pipeline_id = harness_platform_pipeline_execution.test.pipeline_id
+			status = ["Success"]
+		}
+`, testAccResourcePipelineExecution(id, "first"))
+}
@@ -0,0 +1,297 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// runtimeInput is the prefix of the values provided at runtime, which may be followed by methods such as
+// `.default(value)` or `.allowedValues(a,b)`.
+const runtimeInput = "<+input>"
+
+// variableTypes are the types of the pipeline variables, whose value has the type of the variable.
+var variableTypes = map[string]bool{
+	"String": true,
+	"Number": true,
+	"Secret": true,
+}
+
+func DataSourcePipelineInputTemplate() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for retrieving the runtime input template of a Harness pipeline, which is the YAML of an input set providing every runtime input of the pipeline.",
+
+		ReadContext: dataSourcePipelineInputTemplateRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id":     helpers.GetOrgIdSchema(helpers.SchemaFlagTypes.Required),
+			"project_id": helpers.GetProjectIdSchema(helpers.SchemaFlagTypes.Required),
+			"pipeline_id": {
+				Description: "Identifier of the pipeline.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"branch": {
+				Description: "Branch of the pipeline, for pipelines stored in Git.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"stage_ids": {
+				Description: "Identifiers of the stages to run. The template covers the whole pipeline when not set.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"template_yaml": {
+				Description: "Runtime input template of the pipeline. It is empty when the pipeline has no runtime inputs.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"has_input_sets": {
+				Description: "Whether the pipeline has input sets.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"inputs": {
+				Description: "Runtime inputs of the template, in the order of the template.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Description: "Path of the input in the template, such as `pipeline.variables[0].value`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Type of the input. It is the type of the variable for the value of pipeline variables, `String` otherwise.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"default": {
+							Description: "Default value of the input.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"allowed_values": {
+							Description: "Values allowed for the input.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"regex": {
+							Description: "Regular expression the input must match.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"execution_input": {
+							Description: "Whether the input is provided while the pipeline runs.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"required": {
+							Description: "Whether the input must be provided, as it has no default value.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func dataSourcePipelineInputTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	org_id := d.Get("org_id").(string)
+	project_id := d.Get("project_id").(string)
+	pipeline_id := d.Get("pipeline_id").(string)
+
+	opts := &nextgen.InputSetsApiRuntimeInputTemplateOpts{
+		Branch: helpers.BuildField(d, "branch"),
+	}
+	if attr, ok := d.GetOk("stage_ids"); ok {
+		opts.Body = optional.NewInterface(nextgen.InputSetTemplateRequest{
+			StageIdentifiers: helpers.ExpandField(attr.([]interface{})),
+		})
+	}
+
+	resp, httpResp, err := c.InputSetsApi.RuntimeInputTemplate(ctx, c.AccountId, org_id, project_id, pipeline_id, opts)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	template := ""
+	hasInputSets := false
+	if resp.Data != nil {
+		template = resp.Data.InputSetTemplateYaml
+		hasInputSets = resp.Data.HasInputSets
+	}
+
+	inputs, err := parseRuntimeInputs(template)
+	if err != nil {
+		return diag.Errorf("invalid runtime input template: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", org_id, project_id, pipeline_id))
+	d.Set("template_yaml", template)
+	d.Set("has_input_sets", hasInputSets)
+	d.Set("inputs", inputs)
+
+	return nil
+}
+
+// parseRuntimeInputs returns the runtime inputs of a runtime input template.
+func parseRuntimeInputs(template string) ([]interface{}, error) {
+	inputs := []interface{}{}
+	if strings.TrimSpace(template) == "" {
+		return inputs, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &doc); err != nil {
+		return nil, err
+	}
+
+	var visit func(node *yaml.Node, path string, inputType string)
+	visit = func(node *yaml.Node, path string, inputType string) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				visit(child, path, inputType)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				visit(child, fmt.Sprintf("%s[%d]", path, i), "String")
+			}
+		case yaml.MappingNode:
+			variableType := ""
+			for i := 0; i < len(node.Content); i += 2 {
+				if node.Content[i].Value == "type" && variableTypes[node.Content[i+1].Value] {
+					variableType = node.Content[i+1].Value
+				}
+			}
+			for i := 0; i < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				childType := "String"
+				if key == "value" && variableType != "" {
+					childType = variableType
+				}
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				visit(node.Content[i+1], childPath, childType)
+			}
+		case yaml.ScalarNode:
+			if input, ok := parseRuntimeInput(node.Value); ok {
+				input["path"] = path
+				input["type"] = inputType
+				inputs = append(inputs, input)
+			}
+		}
+	}
+	visit(&doc, "", "String")
+
+	return inputs, nil
+}
+
+// parseRuntimeInput parses a runtime input such as `<+input>.default(dev).allowedValues(dev,prod)`. The methods
+// that can't be parsed are ignored.
+func parseRuntimeInput(value string) (map[string]interface{}, bool) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, runtimeInput) {
+		return nil, false
+	}
+
+	input := map[string]interface{}{
+		"default":         "",
+		"allowed_values":  []interface{}{},
+		"regex":           "",
+		"execution_input": false,
+		"required":        true,
+	}
+
+	rest := strings.TrimPrefix(value, runtimeInput)
+	for strings.HasPrefix(rest, ".") {
+		open := strings.Index(rest, "(")
+		if open < 0 {
+			break
+		}
+		end := matchingParenthesis(rest, open)
+		if end < 0 {
+			break
+		}
+		name, args := rest[1:open], rest[open+1:end]
+		rest = rest[end+1:]
+
+		switch name {
+		case "default":
+			input["default"] = args
+			input["required"] = false
+		case "allowedValues", "selectOneFrom":
+			var values []interface{}
+			for _, v := range splitArguments(args) {
+				values = append(values, v)
+			}
+			input["allowed_values"] = values
+		case "regex":
+			input["regex"] = args
+		case "executionInput":
+			input["execution_input"] = true
+		}
+	}
+
+	return input, true
+}
+
+// matchingParenthesis returns the index of the parenthesis closing the one at the given index, or -1.
+func matchingParenthesis(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitArguments splits the comma separated arguments of a method, ignoring the commas between parentheses.
+func splitArguments(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if strings.TrimSpace(s) != "" {
+		args = append(args, strings.TrimSpace(s[start:]))
+	}
+	return args
+}
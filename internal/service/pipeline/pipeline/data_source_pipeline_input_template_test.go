@@ -0,0 +1,206 @@
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/pipeline"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const runtimeInputPipelineYaml = `pipeline:
+  name: Inputs
+  identifier: inputs
+  projectIdentifier: project
+  orgIdentifier: org
+  variables:
+    - name: environment
+      type: String
+      value: <+input>.default(dev).allowedValues(dev,qa,prod)
+    - name: replicas
+      type: Number
+      value: <+input>
+    - name: fixed
+      type: String
+      value: fixed
+  stages:
+    - stage:
+        name: deploy
+        identifier: deploy
+        type: Custom
+        spec:
+          execution:
+            steps:
+              - step:
+                  type: ShellScript
+                  name: shell
+                  identifier: shell
+                  timeout: <+input>.regex(^\d+(m|h)$).executionInput()
+`
+
+func TestDataSourcePipelineInputTemplate(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	server.SeedProject(t, "org", "project")
+	server.Create(t, pipeline.ResourcePipeline(), map[string]interface{}{
+		"identifier": "inputs",
+		"name":       "Inputs",
+		"org_id":     "org",
+		"project_id": "project",
+		"yaml":       runtimeInputPipelineYaml,
+	})
+	server.Create(t, pipeline.ResourcePipeline(), map[string]interface{}{
+		"identifier": "test",
+		"name":       "Test",
+		"org_id":     "org",
+		"project_id": "project",
+		"yaml":       executionPipelineYaml,
+	})
+
+	read := func(pipelineId string) *schema.ResourceData {
+		r := pipeline.DataSourcePipelineInputTemplate()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"org_id":      "org",
+			"project_id":  "project",
+			"pipeline_id": pipelineId,
+		})
+		diags := r.ReadContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		return d
+	}
+
+	t.Run("parses the runtime inputs", func(t *testing.T) {
+		d := read("inputs")
+
+		assert.Equal(t, "org/project/inputs", d.Id())
+		assert.Contains(t, d.Get("template_yaml"), "value: <+input>.default(dev).allowedValues(dev,qa,prod)")
+		assert.NotContains(t, d.Get("template_yaml"), "fixed")
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"path":            "pipeline.variables[0].value",
+				"type":            "String",
+				"default":         "dev",
+				"allowed_values":  []interface{}{"dev", "qa", "prod"},
+				"regex":           "",
+				"execution_input": false,
+				"required":        false,
+			},
+			map[string]interface{}{
+				"path":            "pipeline.variables[1].value",
+				"type":            "Number",
+				"default":         "",
+				"allowed_values":  []interface{}{},
+				"regex":           "",
+				"execution_input": false,
+				"required":        true,
+			},
+			map[string]interface{}{
+				"path":            "pipeline.stages[0].stage.spec.execution.steps[0].step.timeout",
+				"type":            "String",
+				"default":         "",
+				"allowed_values":  []interface{}{},
+				"regex":           `^\d+(m|h)$`,
+				"execution_input": true,
+				"required":        true,
+			},
+		}, d.Get("inputs"))
+	})
+
+	t.Run("returns no inputs without runtime inputs", func(t *testing.T) {
+		d := read("test")
+
+		assert.Equal(t, "", d.Get("template_yaml"))
+		assert.Equal(t, 0, d.Get("inputs.#"))
+	})
+}
+
+func TestAccDataSourcePipelineInputTemplate(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	resourceName := "data.harness_platform_pipeline_input_template.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePipelineInputTemplate(id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "inputs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "inputs.0.path", "pipeline.variables[0].value"),
+					resource.TestCheckResourceAttr(resourceName, "inputs.0.default", "dev"),
+					resource.TestCheckResourceAttr(resourceName, "inputs.0.allowed_values.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePipelineInputTemplate(id string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+		}
+
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[1]s"
+			yaml = <<-EOT
+				pipeline:
+				  name: %[1]s
+				  identifier: %[1]s
+				  projectIdentifier: ${harness_platform_project.test.id}
+				  orgIdentifier: ${harness_platform_project.test.org_id}
+				  variables:
+				    - name: environment
+				      type: String
+				      value: <+input>.default(dev).allowedValues(dev,prod)
+				  stages:
+				    - stage:
+				        name: echo
+				        identifier: echo
+				        type: Custom
+				        spec:
+				          execution:
+				            steps:
+				              - step:
+				                  type: ShellScript
+				                  name: shell
+				                  identifier: shell
+				                  spec:
+				                    shell: Bash
+				                    onDelegate: true
+				                    source:
+				                      type: Inline
+				                      spec:
+				                        script: echo <+pipeline.variables.environment>
+				                    environmentVariables: []
+				                    outputVariables: []
+				                  timeout: 10m
+			EOT
+		}
+
+		data "harness_platform_pipeline_input_template" "test" {
+			org_id = harness_platform_pipeline.test.org_id
+			project_id = harness_platform_pipeline.test.project_id
+			pipeline_id = harness_platform_pipeline.test.id
+		}
+`, id)
+}
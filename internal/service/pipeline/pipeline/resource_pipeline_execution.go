@@ -0,0 +1,362 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// The status of a running execution is checked with an interval doubling from minExecutionPollInterval up to
+// maxExecutionPollInterval, so that short executions complete quickly without polling long ones too often.
+const (
+	minExecutionPollInterval = 1 * time.Second
+	maxExecutionPollInterval = 15 * time.Second
+)
+
+// successfulExecutionStatuses and failedExecutionStatuses are the terminal statuses of a pipeline execution.
+var (
+	successfulExecutionStatuses = map[string]bool{
+		"Success":      true,
+		"IgnoreFailed": true,
+		"Skipped":      true,
+	}
+	failedExecutionStatuses = map[string]bool{
+		"Failed":           true,
+		"Errored":          true,
+		"Aborted":          true,
+		"AbortedByFreeze":  true,
+		"Expired":          true,
+		"ApprovalRejected": true,
+		"Suspended":        true,
+	}
+)
+
+func ResourcePipelineExecution() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Resource for running a Harness pipeline and waiting for its execution to complete. " +
+			"The pipeline runs again when any of the arguments changes, such as `triggers`. " +
+			"Destroying the resource only removes the execution from the state.",
+
+		ReadContext:   resourcePipelineExecutionRead,
+		CreateContext: resourcePipelineExecutionCreate,
+		DeleteContext: resourcePipelineExecutionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id":     helpers.GetOrgIdSchema(helpers.SchemaFlagTypes.Required),
+			"project_id": helpers.GetProjectIdSchema(helpers.SchemaFlagTypes.Required),
+			"pipeline_id": {
+				Description: "Identifier of the pipeline to run.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"module_type": {
+				Description: "Module of the pipeline, such as `cd` or `ci`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "cd",
+			},
+			"input_set_ids": {
+				Description:   "Identifiers of the input sets providing the runtime inputs of the pipeline, merged in order.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"runtime_input_yaml"},
+			},
+			"runtime_input_yaml": {
+				Description:   "Runtime inputs of the pipeline, in the format of the YAML of an input set.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"input_set_ids"},
+			},
+			"branch": {
+				Description: "Branch of the pipeline to run, for pipelines stored in Git.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that runs the pipeline again when changed.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"execution_id": {
+				Description: "Identifier of the execution.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the execution.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"start_ts": {
+				Description: "Time at which the execution started, in milliseconds.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"end_ts": {
+				Description: "Time at which the execution ended, in milliseconds.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"stages": {
+				Description: "Stages of the execution, in the order of the pipeline.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Description: "Identifier of the stage.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the stage.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Status of the stage.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"outputs": {
+							Description: "Output variables of the steps of the stage, keyed by `<step identifier>.<variable name>`.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return resource
+}
+
+func resourcePipelineExecutionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	org_id := d.Get("org_id").(string)
+	project_id := d.Get("project_id").(string)
+	pipeline_id := d.Get("pipeline_id").(string)
+	module_type := d.Get("module_type").(string)
+	branch := helpers.BuildField(d, "branch")
+
+	var resp nextgen.ResponseDtoPlanExecutionResponse
+	var httpResp *http.Response
+	var err error
+	if attr, ok := d.GetOk("input_set_ids"); ok {
+		resp, httpResp, err = c.ExecuteApi.PostPipelineExecuteWithInputSetList(ctx, nextgen.MergeInputSetRequest{
+			InputSetReferences: helpers.ExpandField(attr.([]interface{})),
+		}, c.AccountId, org_id, project_id, module_type, pipeline_id, &nextgen.ExecuteApiPostPipelineExecuteWithInputSetListOpts{
+			Branch: branch,
+		})
+	} else {
+		opts := &nextgen.ExecuteApiPostPipelineExecuteWithInputSetYamlOpts{Branch: branch}
+		if attr, ok := d.GetOk("runtime_input_yaml"); ok {
+			body, err := runtimeInputBody(attr.(string))
+			if err != nil {
+				return diag.Errorf("invalid runtime_input_yaml: %s", err)
+			}
+			opts.Body = optional.NewInterface(body)
+		}
+		resp, httpResp, err = c.ExecuteApi.PostPipelineExecuteWithInputSetYaml(ctx, c.AccountId, org_id, project_id, module_type, pipeline_id, opts)
+	}
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+	if resp.Data == nil || resp.Data.PlanExecution == nil {
+		return diag.Errorf("the execution of the pipeline %s was not started", pipeline_id)
+	}
+
+	// The execution is saved in the state before waiting for it, so that a failed execution taints the resource.
+	execution_id := resp.Data.PlanExecution.Uuid
+	d.SetId(execution_id)
+	d.Set("execution_id", execution_id)
+
+	summary, err := waitForPipelineExecution(ctx, c, org_id, project_id, execution_id)
+	if err != nil {
+		d.Set("status", resp.Data.PlanExecution.Status)
+		return diag.FromErr(err)
+	}
+
+	if diags := resourcePipelineExecutionRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	if failedExecutionStatuses[summary.Status] {
+		message := ""
+		if summary.ExecutionErrorInfo != nil && summary.ExecutionErrorInfo.Message != "" {
+			message = ": " + summary.ExecutionErrorInfo.Message
+		}
+		return diag.Errorf("the execution %s of the pipeline %s ended with the status %s%s", execution_id, pipeline_id, summary.Status, message)
+	}
+
+	return nil
+}
+
+// runtimeInputBody returns the runtime input yaml as the body of the execute request. The API client encodes the
+// body in JSON, which the API reads as yaml since JSON is a subset of yaml.
+func runtimeInputBody(s string) (json.RawMessage, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// waitForPipelineExecution polls the execution until it reaches a terminal status or the context is done.
+func waitForPipelineExecution(ctx context.Context, c *nextgen.APIClient, org_id string, project_id string, execution_id string) (*nextgen.PipelineExecutionSummary, error) {
+	interval := minExecutionPollInterval
+	for {
+		resp, _, err := c.ExecutionDetailsApi.GetExecutionDetailV2(ctx, c.AccountId, org_id, project_id, execution_id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.Data != nil && resp.Data.PipelineExecutionSummary != nil {
+			summary := resp.Data.PipelineExecutionSummary
+			if successfulExecutionStatuses[summary.Status] || failedExecutionStatuses[summary.Status] {
+				return summary, nil
+			}
+		}
+
+		select {
+		case <-time.After(interval):
+			interval = min(2*interval, maxExecutionPollInterval)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout waiting for the execution %s to complete: %w", execution_id, ctx.Err())
+		}
+	}
+}
+
+func resourcePipelineExecutionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	org_id := d.Get("org_id").(string)
+	project_id := d.Get("project_id").(string)
+
+	resp, httpResp, err := c.ExecutionDetailsApi.GetExecutionDetailV2(ctx, c.AccountId, org_id, project_id, d.Id(), nil)
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+	if resp.Data == nil || resp.Data.PipelineExecutionSummary == nil {
+		d.SetId("")
+		d.MarkNewResource()
+		return nil
+	}
+
+	summary := resp.Data.PipelineExecutionSummary
+	stages := []interface{}{}
+	for _, node := range getExecutionStages(summary) {
+		stageResp, httpResp, err := c.ExecutionDetailsApi.GetExecutionDetailV2(ctx, c.AccountId, org_id, project_id, d.Id(), &nextgen.ExecutionDetailsApiGetExecutionDetailV2Opts{
+			StageNodeId: optional.NewString(node.NodeUuid),
+		})
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+		stages = append(stages, map[string]interface{}{
+			"identifier": node.NodeIdentifier,
+			"name":       node.Name,
+			"status":     node.Status,
+			"outputs":    getStageOutputs(stageResp.Data),
+		})
+	}
+
+	d.Set("execution_id", summary.PlanExecutionId)
+	d.Set("pipeline_id", summary.PipelineIdentifier)
+	d.Set("status", summary.Status)
+	d.Set("start_ts", summary.StartTs)
+	d.Set("end_ts", summary.EndTs)
+	d.Set("stages", stages)
+
+	return nil
+}
+
+// resourcePipelineExecutionDelete only removes the execution from the state, as executions can't be deleted.
+func resourcePipelineExecutionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// getExecutionStages returns the stages of the layout of an execution, in the order in which they appear in the
+// pipeline. Parallel stages are grouped under a node whose children are the stages.
+func getExecutionStages(summary *nextgen.PipelineExecutionSummary) []nextgen.GraphLayoutNode {
+	var stages []nextgen.GraphLayoutNode
+	visited := map[string]bool{}
+
+	var visit func(ids []string)
+	visit = func(ids []string) {
+		for _, id := range ids {
+			node, ok := summary.LayoutNodeMap[id]
+			if !ok || visited[id] {
+				continue
+			}
+			visited[id] = true
+
+			if node.NodeGroup == "STAGE" && node.NodeType != "parallel" {
+				if node.NodeUuid == "" {
+					node.NodeUuid = id
+				}
+				stages = append(stages, node)
+			}
+			if node.EdgeLayoutList != nil {
+				visit(node.EdgeLayoutList.CurrentNodeChildren)
+				visit(node.EdgeLayoutList.NextIds)
+			}
+		}
+	}
+	visit([]string{summary.StartingNodeId})
+
+	return stages
+}
+
+// getStageOutputs returns the output variables of the steps of a stage, found in the outcomes of the nodes of the
+// execution graph of the stage.
+func getStageOutputs(detail *nextgen.PipelineExecutionDetail) map[string]interface{} {
+	outputs := map[string]interface{}{}
+	if detail == nil || detail.ExecutionGraph == nil {
+		return outputs
+	}
+
+	ids := make([]string, 0, len(detail.ExecutionGraph.NodeMap))
+	for id := range detail.ExecutionGraph.NodeMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		node := detail.ExecutionGraph.NodeMap[id]
+		variables, ok := node.Outcomes["output"]["outputVariables"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, value := range variables {
+			outputs[node.Identifier+"."+name] = fmt.Sprint(value)
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,209 @@
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/pipeline"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const executionPipelineYaml = `pipeline:
+  name: Test
+  identifier: test
+  projectIdentifier: project
+  orgIdentifier: org
+  stages: []
+`
+
+func TestResourcePipelineExecution(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	server.SeedProject(t, "org", "project")
+	server.Create(t, pipeline.ResourcePipeline(), map[string]interface{}{
+		"identifier": "test",
+		"name":       "Test",
+		"org_id":     "org",
+		"project_id": "project",
+		"yaml":       executionPipelineYaml,
+	})
+
+	t.Run("waits for the execution", func(t *testing.T) {
+		server.SetExecutionStatuses("Running", "Success")
+		d := server.Create(t, pipeline.ResourcePipelineExecution(), map[string]interface{}{
+			"org_id":        "org",
+			"project_id":    "project",
+			"pipeline_id":   "test",
+			"input_set_ids": []interface{}{"first", "second"},
+		})
+
+		assert.Equal(t, d.Id(), d.Get("execution_id"))
+		assert.Equal(t, "Success", d.Get("status"))
+		assert.NotZero(t, d.Get("end_ts"))
+		assert.Equal(t, 1, d.Get("stages.#"))
+		assert.Equal(t, "stage", d.Get("stages.0.identifier"))
+		assert.Equal(t, "Success", d.Get("stages.0.status"))
+		assert.Equal(t, map[string]interface{}{"step.status": "Success"}, d.Get("stages.0.outputs"))
+
+		inputSets, inputs := server.ExecutionInputs(d.Id())
+		assert.Equal(t, []string{"first", "second"}, inputSets)
+		assert.Nil(t, inputs)
+	})
+
+	t.Run("sends the runtime inputs", func(t *testing.T) {
+		server.SetExecutionStatuses()
+		d := server.Create(t, pipeline.ResourcePipelineExecution(), map[string]interface{}{
+			"org_id":      "org",
+			"project_id":  "project",
+			"pipeline_id": "test",
+			"runtime_input_yaml": `pipeline:
+  identifier: test
+  variables:
+    - name: version
+      type: String
+      value: "1.0"
+`,
+		})
+
+		_, inputs := server.ExecutionInputs(d.Id())
+		assert.Equal(t, map[string]interface{}{
+			"pipeline": map[string]interface{}{
+				"identifier": "test",
+				"variables": []interface{}{
+					map[string]interface{}{"name": "version", "type": "String", "value": "1.0"},
+				},
+			},
+		}, inputs)
+	})
+
+	t.Run("fails when the execution fails", func(t *testing.T) {
+		server.SetExecutionStatuses("Failed")
+		r := pipeline.ResourcePipelineExecution()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"org_id":      "org",
+			"project_id":  "project",
+			"pipeline_id": "test",
+		})
+		diags := r.CreateContext(ctx, d, session)
+
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "ended with the status Failed: Step failed")
+		// The failed execution is kept in the state, so that the resource is tainted.
+		assert.NotEmpty(t, d.Id())
+		assert.Equal(t, "Failed", d.Get("status"))
+	})
+
+	t.Run("fails when the pipeline does not exist", func(t *testing.T) {
+		r := pipeline.ResourcePipelineExecution()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"org_id":      "org",
+			"project_id":  "project",
+			"pipeline_id": "missing",
+		})
+		diags := r.CreateContext(ctx, d, session)
+
+		require.True(t, diags.HasError())
+		assert.Empty(t, d.Id())
+	})
+}
+
+func TestAccResourcePipelineExecution(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	resourceName := "harness_platform_pipeline_execution.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineExecution(id, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pipeline_id", id),
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "stages.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "stages.0.identifier", "echo"),
+					resource.TestCheckResourceAttr(resourceName, "stages.0.outputs.shell.message", "hello"),
+				),
+			},
+			{
+				Config: testAccResourcePipelineExecution(id, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePipelineExecution(id string, run string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+		}
+
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[1]s"
+			yaml = <<-EOT
+				pipeline:
+				  name: %[1]s
+				  identifier: %[1]s
+				  projectIdentifier: ${harness_platform_project.test.id}
+				  orgIdentifier: ${harness_platform_project.test.org_id}
+				  stages:
+				    - stage:
+				        name: echo
+				        identifier: echo
+				        type: Custom
+				        spec:
+				          execution:
+				            steps:
+				              - step:
+				                  type: ShellScript
+				                  name: shell
+				                  identifier: shell
+				                  spec:
+				                    shell: Bash
+				                    onDelegate: true
+				                    source:
+				                      type: Inline
+				                      spec:
+				                        script: message=hello
+				                    environmentVariables: []
+				                    outputVariables:
+				                      - name: message
+				                        type: String
+				                        value: message
+				                  timeout: 10m
+			EOT
+		}
+
+		resource "harness_platform_pipeline_execution" "test" {
+			org_id = harness_platform_pipeline.test.org_id
+			project_id = harness_platform_pipeline.test.project_id
+			pipeline_id = harness_platform_pipeline.test.id
+			module_type = "cd"
+			triggers = {
+				run = "%[2]s"
+			}
+		}
+`, id, run)
+}
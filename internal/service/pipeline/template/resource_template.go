@@ -22,14 +22,15 @@ func ResourceTemplate() *schema.Resource {
 		UpdateContext: resourceTemplateCreateOrUpdate,
 		DeleteContext: resourceTemplateDelete,
 		CreateContext: resourceTemplateCreateOrUpdate,
-		Importer:      helpers.MultiLevelResourceImporter,
+		Importer:      helpers.MultiLevelGitResourceImporter,
 
 		Schema: map[string]*schema.Schema{
 			"template_yaml": {
-				Description: "Yaml for creating new Template." + helpers.Descriptions.YamlText.String(),
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
+				Description:      "Yaml for creating new Template." + helpers.Descriptions.YamlText.String(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunction,
 			},
 			"version": {
 				Description: "Version Label for Template.",
@@ -221,6 +222,8 @@ func ResourceTemplate() *schema.Resource {
 		},
 	}
 
+	helpers.SetYamlValidation(resource, "template_yaml", helpers.YamlKinds.Template)
+
 	return resource
 }
 
@@ -47,6 +47,8 @@ func ResourceTriggers() *schema.Resource {
 		},
 	}
 	helpers.SetProjectLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Project)
+	helpers.SetYamlValidation(resource, "yaml", helpers.YamlKinds.Trigger)
 
 	return resource
 }
@@ -47,6 +47,7 @@ func ResourceDBInstance() *schema.Resource {
 	}
 
 	helpers.SetProjectLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Project)
 
 	return resource
 }
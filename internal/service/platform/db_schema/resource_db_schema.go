@@ -62,6 +62,7 @@ func ResourceDBSchema() *schema.Resource {
 	}
 
 	helpers.SetProjectLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Project)
 
 	return resource
 }
@@ -0,0 +1,938 @@
+package import_blocks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	openapi_client_nextgen "github.com/harness/harness-openapi-go-client/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const pageSize = 100
+
+func DataSourceImportBlocks() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Data source for discovering the entities of an account, organization or project and generating the Terraform `import` blocks to bring them under management. " +
+			"Only the entities of the given scope are listed: organizations are listed at the account level and projects at the organization level. " +
+			"Only a subset of the resource types of the provider is supported, see `resource_types`: the entities of the other resource types are not listed and must be imported manually, a warning names these resource types when `resource_types` is not set.",
+
+		ReadContext: dataSourceImportBlocksRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_types": {
+				Description: fmt.Sprintf("Resource types to discover. Defaults to all the supported resource types: %s.", strings.Join(SupportedResourceTypes(), ", ")),
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(SupportedResourceTypes(), false),
+				},
+			},
+			"resources": {
+				Description: "Entities discovered in the scope.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Description: "Terraform resource type of the entity.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"identifier": {
+							Description: "Unique identifier of the entity.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the entity.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"address": {
+							Description: "Address of the resource in the generated import block.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"import_id": {
+							Description: "Id to import the resource with.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"content": {
+				Description: "Terraform `import` blocks for all the discovered entities.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+
+	helpers.SetOptionalOrgAndProjectLevelDataSourceSchema(resource.Schema)
+	resource.Schema["project_id"].RequiredWith = []string{"org_id"}
+
+	return resource
+}
+
+type scope struct {
+	orgId     string
+	projectId string
+}
+
+// discoveredEntity is an entity that can be imported as resourceType. Entities of types that are not supported by
+// the provider have an empty resourceType.
+type discoveredEntity struct {
+	resourceType string
+	identifier   string
+	name         string
+	importId     string
+}
+
+// discoverer lists the entities of a kind in a scope, which are managed by one or more resource types.
+type discoverer struct {
+	kind          string
+	resourceTypes []string
+	list          func(ctx context.Context, session *internal.Session, scope scope) ([]discoveredEntity, *http.Response, error)
+}
+
+var discoverers = []discoverer{
+	{"organizations", []string{"harness_platform_organization"}, listOrganizations},
+	{"projects", []string{"harness_platform_project"}, listProjects},
+	{"connectors", connectorResourceTypes(), listConnectors},
+	{"secrets", secretResourceTypes(), listSecrets},
+	{"services", []string{"harness_platform_service"}, listServices},
+	{"environments", []string{"harness_platform_environment"}, listEnvironments},
+	{"pipelines", []string{"harness_platform_pipeline"}, listPipelines},
+	{"user groups", []string{"harness_platform_usergroup"}, listUserGroups},
+	{"service accounts", []string{"harness_platform_service_account"}, listServiceAccounts},
+	{"infrastructures", []string{"harness_platform_infrastructure"}, listInfrastructures},
+	{"input sets", []string{"harness_platform_input_set", "harness_platform_overlay_input_set"}, listInputSets},
+	{"triggers", []string{"harness_platform_triggers"}, listTriggers},
+	{"templates", []string{"harness_platform_template"}, listTemplates},
+	{"roles", []string{"harness_platform_roles"}, listRoles},
+	{"resource groups", []string{"harness_platform_resource_group"}, listResourceGroups},
+	{"variables", []string{"harness_platform_variables"}, listVariables},
+}
+
+// SupportedResourceTypes returns the resource types that can be discovered.
+func SupportedResourceTypes() []string {
+	var resourceTypes []string
+	for _, discoverer := range discoverers {
+		resourceTypes = append(resourceTypes, discoverer.resourceTypes...)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+// UndiscoveredResourceTypes returns the NextGen resource types of the provider that support imports but whose
+// entities are not discovered by the data source.
+func UndiscoveredResourceTypes(resources map[string]*schema.Resource) []string {
+	supported := map[string]bool{}
+	for _, resourceType := range SupportedResourceTypes() {
+		supported[resourceType] = true
+	}
+
+	var resourceTypes []string
+	for resourceType, r := range resources {
+		if strings.HasPrefix(resourceType, "harness_platform_") && r.Importer != nil && !supported[resourceType] {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+// SetUndiscoveredResourceTypes makes the data source warn about the resource types of the provider whose entities
+// are not discovered when all the supported resource types are requested, as they must be imported manually.
+func SetUndiscoveredResourceTypes(r *schema.Resource, resources map[string]*schema.Resource) {
+	undiscovered := UndiscoveredResourceTypes(resources)
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := read(ctx, d, meta)
+		if _, ok := d.GetOk("resource_types"); ok || diags.HasError() || len(undiscovered) == 0 {
+			return diags
+		}
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d resource types were not discovered", len(undiscovered)),
+			Detail:   "The entities of these resource types are not listed and must be imported manually: " + strings.Join(undiscovered, ", ") + ".",
+		})
+	}
+}
+
+func dataSourceImportBlocksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	session := meta.(*internal.Session)
+	s := scope{orgId: d.Get("org_id").(string), projectId: d.Get("project_id").(string)}
+
+	requested := map[string]bool{}
+	if v, ok := d.GetOk("resource_types"); ok {
+		for _, resourceType := range v.(*schema.Set).List() {
+			requested[resourceType.(string)] = true
+		}
+	} else {
+		for _, resourceType := range SupportedResourceTypes() {
+			requested[resourceType] = true
+		}
+	}
+
+	var diags diag.Diagnostics
+	var resources []map[string]interface{}
+	var blocks []string
+	addresses := map[string]bool{}
+
+	for _, discoverer := range discoverers {
+		if !requestsAny(requested, discoverer.resourceTypes) {
+			continue
+		}
+
+		entities, httpResp, err := discoverer.list(ctx, session, s)
+		if err != nil {
+			return helpers.HandleApiError(err, d, httpResp)
+		}
+
+		skipped := 0
+		for _, entity := range entities {
+			if entity.resourceType == "" {
+				skipped++
+				continue
+			}
+			if !requested[entity.resourceType] {
+				continue
+			}
+
+			address := uniqueAddress(addresses, entity.resourceType+"."+resourceName(entity.identifier))
+			resources = append(resources, map[string]interface{}{
+				"resource_type": entity.resourceType,
+				"identifier":    entity.identifier,
+				"name":          entity.name,
+				"address":       address,
+				"import_id":     entity.importId,
+			})
+			blocks = append(blocks, fmt.Sprintf("import {\n  to = %s\n  id = %q\n}\n", address, entity.importId))
+		}
+
+		if skipped > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%d %s were skipped", skipped, discoverer.kind),
+				Detail:   "The provider has no resource for their type.",
+			})
+		}
+	}
+
+	d.SetId(strings.TrimRight(strings.Join([]string{session.AccountId, s.orgId, s.projectId}, "/"), "/"))
+	d.Set("resources", resources)
+	d.Set("content", strings.Join(blocks, "\n"))
+
+	return diags
+}
+
+func requestsAny(requested map[string]bool, resourceTypes []string) bool {
+	for _, resourceType := range resourceTypes {
+		if requested[resourceType] {
+			return true
+		}
+	}
+	return false
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// resourceName converts an identifier to a valid Terraform resource name.
+func resourceName(identifier string) string {
+	name := invalidNameCharacters.ReplaceAllString(identifier, "_")
+	if name == "" || !(name[0] == '_' || (name[0] >= 'a' && name[0] <= 'z') || (name[0] >= 'A' && name[0] <= 'Z')) {
+		name = "_" + name
+	}
+	return name
+}
+
+func uniqueAddress(addresses map[string]bool, address string) string {
+	unique := address
+	for i := 2; addresses[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", address, i)
+	}
+	addresses[unique] = true
+	return unique
+}
+
+// optionalString omits the org and project query parameters at the levels above them.
+func optionalString(v string) optional.String {
+	if v == "" {
+		return optional.EmptyString()
+	}
+	return optional.NewString(v)
+}
+
+func listOrganizations(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	if s.orgId != "" {
+		return nil, nil, nil
+	}
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.OrganizationApi.GetOrganizationList(ctx, c.AccountId, &nextgen.OrganizationApiGetOrganizationListOpts{
+			PageIndex: optional.NewInt32(page),
+			PageSize:  optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, org := range resp.Data.Content {
+			if org.Organization == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_organization",
+				identifier:   org.Organization.Identifier,
+				name:         org.Organization.Name,
+				importId:     org.Organization.Identifier,
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listProjects(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	if s.orgId == "" || s.projectId != "" {
+		return nil, nil, nil
+	}
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.ProjectApi.GetProjectList(ctx, c.AccountId, &nextgen.ProjectApiGetProjectListOpts{
+			OrgIdentifier: optional.NewString(s.orgId),
+			PageIndex:     optional.NewInt32(page),
+			PageSize:      optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, project := range resp.Data.Content {
+			if project.Project == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_project",
+				identifier:   project.Project.Identifier,
+				name:         project.Project.Name,
+				importId:     helpers.FormatImportId(s.orgId, "", "", project.Project.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+// connectorResources maps the connector types to the resources managing them.
+var connectorResources = map[nextgen.ConnectorType]string{
+	nextgen.ConnectorTypes.K8sCluster:          "harness_platform_connector_kubernetes",
+	nextgen.ConnectorTypes.Git:                 "harness_platform_connector_git",
+	nextgen.ConnectorTypes.Splunk:              "harness_platform_connector_splunk",
+	nextgen.ConnectorTypes.AppDynamics:         "harness_platform_connector_appdynamics",
+	nextgen.ConnectorTypes.Prometheus:          "harness_platform_connector_prometheus",
+	nextgen.ConnectorTypes.Dynatrace:           "harness_platform_connector_dynatrace",
+	nextgen.ConnectorTypes.Vault:               "harness_platform_connector_vault",
+	nextgen.ConnectorTypes.AzureKeyVault:       "harness_platform_connector_azure_key_vault",
+	nextgen.ConnectorTypes.DockerRegistry:      "harness_platform_connector_docker",
+	nextgen.ConnectorTypes.JDBC:                "harness_platform_connector_jdbc",
+	nextgen.ConnectorTypes.AwsKms:              "harness_platform_connector_awskms",
+	nextgen.ConnectorTypes.GcpKms:              "harness_platform_connector_gcp_kms",
+	nextgen.ConnectorTypes.AwsSecretManager:    "harness_platform_connector_aws_secret_manager",
+	nextgen.ConnectorTypes.Gcp:                 "harness_platform_connector_gcp",
+	nextgen.ConnectorTypes.Aws:                 "harness_platform_connector_aws",
+	nextgen.ConnectorTypes.Artifactory:         "harness_platform_connector_artifactory",
+	nextgen.ConnectorTypes.Jira:                "harness_platform_connector_jira",
+	nextgen.ConnectorTypes.Jenkins:             "harness_platform_connector_jenkins",
+	nextgen.ConnectorTypes.Nexus:               "harness_platform_connector_nexus",
+	nextgen.ConnectorTypes.Github:              "harness_platform_connector_github",
+	nextgen.ConnectorTypes.Gitlab:              "harness_platform_connector_gitlab",
+	nextgen.ConnectorTypes.Bitbucket:           "harness_platform_connector_bitbucket",
+	nextgen.ConnectorTypes.CEAws:               "harness_platform_connector_awscc",
+	nextgen.ConnectorTypes.CEAzure:             "harness_platform_connector_azure_cloud_cost",
+	nextgen.ConnectorTypes.GcpCloudCost:        "harness_platform_connector_gcp_cloud_cost",
+	nextgen.ConnectorTypes.CEK8sCluster:        "harness_platform_connector_kubernetes_cloud_cost",
+	nextgen.ConnectorTypes.HttpHelmRepo:        "harness_platform_connector_helm",
+	nextgen.ConnectorTypes.OciHelmRepo:         "harness_platform_connector_oci_helm",
+	nextgen.ConnectorTypes.NewRelic:            "harness_platform_connector_newrelic",
+	nextgen.ConnectorTypes.Datadog:             "harness_platform_connector_datadog",
+	nextgen.ConnectorTypes.SumoLogic:           "harness_platform_connector_sumologic",
+	nextgen.ConnectorTypes.PagerDuty:           "harness_platform_connector_pagerduty",
+	nextgen.ConnectorTypes.GcpSecretManager:    "harness_platform_connector_gcp_secret_manager",
+	nextgen.ConnectorTypes.Azure:               "harness_platform_connector_azure_cloud_provider",
+	nextgen.ConnectorTypes.AzureArtifacts:      "harness_platform_connector_azure_artifacts",
+	nextgen.ConnectorTypes.Spot:                "harness_platform_connector_spot",
+	nextgen.ConnectorTypes.ServiceNow:          "harness_platform_connector_service_now",
+	nextgen.ConnectorTypes.Tas:                 "harness_platform_connector_tas",
+	nextgen.ConnectorTypes.TerraformCloud:      "harness_platform_connector_terraform_cloud",
+	nextgen.ConnectorTypes.ElasticSearch:       "harness_platform_connector_elasticsearch",
+	nextgen.ConnectorTypes.Rancher:             "harness_platform_connector_rancher",
+	nextgen.ConnectorTypes.CustomHealth:        "harness_platform_connector_customhealthsource",
+	nextgen.ConnectorTypes.Pdc:                 "harness_platform_connector_pdc",
+	nextgen.ConnectorTypes.CustomSecretManager: "harness_platform_connector_custom_secret_manager",
+}
+
+func connectorResourceTypes() []string {
+	var resourceTypes []string
+	for _, resourceType := range connectorResources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+func listConnectors(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.ConnectorsApi.GetConnectorList(ctx, c.AccountId, &nextgen.ConnectorsApiGetConnectorListOpts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, connector := range resp.Data.Content {
+			if connector.Connector == nil {
+				continue
+			}
+			// The built-in Harness secret manager can't be managed with Terraform.
+			if connector.Connector.Type_ == nextgen.ConnectorTypes.Local {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: connectorResources[connector.Connector.Type_],
+				identifier:   connector.Connector.Identifier,
+				name:         connector.Connector.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", connector.Connector.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+// secretResources maps the secret types to the resources managing them.
+var secretResources = map[nextgen.SecretType]string{
+	nextgen.SecretTypes.SecretText: "harness_platform_secret_text",
+	nextgen.SecretTypes.SecretFile: "harness_platform_secret_file",
+	nextgen.SecretTypes.SSHKey:     "harness_platform_secret_sshkey",
+}
+
+func secretResourceTypes() []string {
+	var resourceTypes []string
+	for _, resourceType := range secretResources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+func listSecrets(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.SecretsApi.ListSecretsV2(ctx, c.AccountId, &nextgen.SecretsApiListSecretsV2Opts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, secret := range resp.Data.Content {
+			if secret.Secret == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: secretResources[secret.Secret.Type_],
+				identifier:   secret.Secret.Identifier,
+				name:         secret.Secret.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", secret.Secret.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listServices(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.ServicesApi.GetServiceList(ctx, c.AccountId, &nextgen.ServicesApiGetServiceListOpts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			Page:              optional.NewInt32(page),
+			Size:              optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, service := range resp.Data.Content {
+			if service.Service == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_service",
+				identifier:   service.Service.Identifier,
+				name:         service.Service.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", service.Service.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listEnvironments(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.EnvironmentsApi.GetEnvironmentList(ctx, c.AccountId, &nextgen.EnvironmentsApiGetEnvironmentListOpts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			Page:              optional.NewInt32(page),
+			Size:              optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, env := range resp.Data.Content {
+			if env.Environment == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_environment",
+				identifier:   env.Environment.Identifier,
+				name:         env.Environment.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", env.Environment.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listPipelines(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	if s.projectId == "" {
+		return nil, nil, nil
+	}
+	c, ctx := session.GetClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.PipelinesApi.ListPipelines(ctx, s.orgId, s.projectId, &openapi_client_nextgen.PipelinesApiListPipelinesOpts{
+			HarnessAccount: optional.NewString(c.AccountId),
+			Page:           optional.NewInt32(page),
+			Limit:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		for _, pipeline := range resp {
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_pipeline",
+				identifier:   pipeline.Identifier,
+				name:         pipeline.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", pipeline.Identifier),
+			})
+		}
+		if len(resp) < pageSize {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listUserGroups(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.UserGroupApi.GetUserGroupList(ctx, c.AccountId, &nextgen.UserGroupApiGetUserGroupListOpts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, userGroup := range resp.Data.Content {
+			// The identifiers of the user groups managed by Harness, like _account_all_users, start with an underscore.
+			if strings.HasPrefix(userGroup.Identifier, "_") {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_usergroup",
+				identifier:   userGroup.Identifier,
+				name:         userGroup.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", userGroup.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listServiceAccounts(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.ServiceAccountApi.ListAggregatedServiceAccounts(ctx, c.AccountId, &nextgen.ServiceAccountApiListAggregatedServiceAccountsOpts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, serviceAccount := range resp.Data.Content {
+			if serviceAccount.ServiceAccount == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_service_account",
+				identifier:   serviceAccount.ServiceAccount.Identifier,
+				name:         serviceAccount.ServiceAccount.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", serviceAccount.ServiceAccount.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listInfrastructures(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	environments, httpResp, err := listEnvironments(ctx, session, s)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for _, env := range environments {
+		for page := int32(0); ; page++ {
+			resp, httpResp, err := c.InfrastructuresApi.GetInfrastructureList(ctx, c.AccountId, env.identifier, &nextgen.InfrastructuresApiGetInfrastructureListOpts{
+				OrgIdentifier:     optionalString(s.orgId),
+				ProjectIdentifier: optionalString(s.projectId),
+				Page:              optional.NewInt32(page),
+				Size:              optional.NewInt32(pageSize),
+			})
+			if err != nil {
+				return nil, httpResp, err
+			}
+			if resp.Data == nil {
+				break
+			}
+			for _, infra := range resp.Data.Content {
+				if infra.Infrastructure == nil {
+					continue
+				}
+				entities = append(entities, discoveredEntity{
+					resourceType: "harness_platform_infrastructure",
+					identifier:   infra.Infrastructure.Identifier,
+					name:         infra.Infrastructure.Name,
+					importId:     helpers.FormatImportId(s.orgId, s.projectId, "", env.identifier, infra.Infrastructure.Identifier),
+				})
+			}
+			if int64(page)+1 >= resp.Data.TotalPages {
+				break
+			}
+		}
+	}
+	return entities, nil, nil
+}
+
+// inputSetKind tells the input sets and the overlay input sets apart, which are returned by the same endpoints.
+type inputSetKind struct {
+	OverlayInputSet interface{} `yaml:"overlayInputSet"`
+}
+
+func listInputSets(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	pipelines, httpResp, err := listPipelines(ctx, session, s)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	c, ctx := session.GetClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for _, pipeline := range pipelines {
+		for page := int32(0); ; page++ {
+			resp, httpResp, err := c.InputSetsApi.ListInputSets(ctx, s.orgId, s.projectId, pipeline.identifier, &openapi_client_nextgen.InputSetsApiListInputSetsOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(pageSize),
+			})
+			if err != nil {
+				return nil, httpResp, err
+			}
+			for _, inputSet := range resp {
+				resourceType := "harness_platform_input_set"
+				var kind inputSetKind
+				if err := yaml.Unmarshal([]byte(inputSet.InputSetYaml), &kind); err == nil && kind.OverlayInputSet != nil {
+					resourceType = "harness_platform_overlay_input_set"
+				}
+				entities = append(entities, discoveredEntity{
+					resourceType: resourceType,
+					identifier:   inputSet.Identifier,
+					name:         inputSet.Name,
+					importId:     helpers.FormatImportId(s.orgId, s.projectId, "", pipeline.identifier, inputSet.Identifier),
+				})
+			}
+			if len(resp) < pageSize {
+				break
+			}
+		}
+	}
+	return entities, nil, nil
+}
+
+func listTriggers(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	pipelines, httpResp, err := listPipelines(ctx, session, s)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for _, pipeline := range pipelines {
+		for page := int32(0); ; page++ {
+			resp, httpResp, err := c.TriggersApi.GetListForTarget(ctx, c.AccountId, s.orgId, s.projectId, pipeline.identifier, &nextgen.TriggersApiGetListForTargetOpts{
+				Page: optional.NewInt32(page),
+				Size: optional.NewInt32(pageSize),
+			})
+			if err != nil {
+				return nil, httpResp, err
+			}
+			if resp.Data == nil {
+				break
+			}
+			for _, trigger := range resp.Data.Content {
+				entities = append(entities, discoveredEntity{
+					resourceType: "harness_platform_triggers",
+					identifier:   trigger.Identifier,
+					name:         trigger.Name,
+					importId:     helpers.FormatImportId(s.orgId, s.projectId, "", pipeline.identifier, trigger.Identifier),
+				})
+			}
+			if int64(page)+1 >= resp.Data.TotalPages {
+				break
+			}
+		}
+	}
+	return entities, nil, nil
+}
+
+func listTemplates(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	listed := map[string]bool{}
+	for page := int32(0); ; page++ {
+		var resp []openapi_client_nextgen.TemplateMetadataSummaryResponse
+		var httpResp *http.Response
+		var err error
+		switch {
+		case s.projectId != "":
+			resp, httpResp, err = c.ProjectTemplateApi.GetTemplatesListProject(ctx, s.orgId, s.projectId, &openapi_client_nextgen.ProjectTemplateApiGetTemplatesListProjectOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(pageSize),
+			})
+		case s.orgId != "":
+			resp, httpResp, err = c.OrgTemplateApi.GetTemplatesListOrg(ctx, s.orgId, &openapi_client_nextgen.OrgTemplateApiGetTemplatesListOrgOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(pageSize),
+			})
+		default:
+			resp, httpResp, err = c.AccountTemplateApi.GetTemplatesListAcc(ctx, &openapi_client_nextgen.AccountTemplateApiGetTemplatesListAccOpts{
+				HarnessAccount: optional.NewString(c.AccountId),
+				Page:           optional.NewInt32(page),
+				Limit:          optional.NewInt32(pageSize),
+			})
+		}
+		if err != nil {
+			return nil, httpResp, err
+		}
+		for _, template := range resp {
+			// The versions of a template are imported as one resource, which reads the stable version.
+			if listed[template.Identifier] {
+				continue
+			}
+			listed[template.Identifier] = true
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_template",
+				identifier:   template.Identifier,
+				name:         template.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", template.Identifier),
+			})
+		}
+		if len(resp) < pageSize {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listRoles(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.RolesApi.GetRoleList(ctx, &nextgen.RolesApiGetRoleListOpts{
+			AccountIdentifier: optional.NewString(c.AccountId),
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, role := range resp.Data.Content {
+			// The built-in roles are managed by Harness.
+			if role.Role == nil || role.HarnessManaged {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_roles",
+				identifier:   role.Role.Identifier,
+				name:         role.Role.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", role.Role.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listResourceGroups(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.HarnessResourceGroupApi.GetResourceGroupListV2(ctx, c.AccountId, &nextgen.HarnessResourceGroupApiGetResourceGroupListV2Opts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, resourceGroup := range resp.Data.Content {
+			// The built-in resource groups, like _all_resources_including_child_scopes, are managed by Harness.
+			if resourceGroup.ResourceGroup == nil || resourceGroup.HarnessManaged {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_resource_group",
+				identifier:   resourceGroup.ResourceGroup.Identifier,
+				name:         resourceGroup.ResourceGroup.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", resourceGroup.ResourceGroup.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
+
+func listVariables(ctx context.Context, session *internal.Session, s scope) ([]discoveredEntity, *http.Response, error) {
+	c, ctx := session.GetPlatformClientWithContext(ctx)
+
+	var entities []discoveredEntity
+	for page := int32(0); ; page++ {
+		resp, httpResp, err := c.VariablesApi.GetVariableList(ctx, c.AccountId, &nextgen.VariablesApiGetVariableListOpts{
+			OrgIdentifier:     optionalString(s.orgId),
+			ProjectIdentifier: optionalString(s.projectId),
+			PageIndex:         optional.NewInt32(page),
+			PageSize:          optional.NewInt32(pageSize),
+		})
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if resp.Data == nil {
+			return entities, nil, nil
+		}
+		for _, variable := range resp.Data.Content {
+			if variable.Variable == nil {
+				continue
+			}
+			entities = append(entities, discoveredEntity{
+				resourceType: "harness_platform_variables",
+				identifier:   variable.Variable.Identifier,
+				name:         variable.Variable.Name,
+				importId:     helpers.FormatImportId(s.orgId, s.projectId, "", variable.Variable.Identifier),
+			})
+		}
+		if int64(page)+1 >= resp.Data.TotalPages {
+			return entities, nil, nil
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package import_blocks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/provider"
+	"github.com/harness/terraform-provider-harness/internal/service/cd_nextgen/service"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/input_set"
+	"github.com/harness/terraform-provider-harness/internal/service/pipeline/pipeline"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/connector"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/import_blocks"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/secret"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pipelineYaml = `pipeline:
+  name: Test
+  identifier: test
+  projectIdentifier: project
+  orgIdentifier: org
+  stages: []
+`
+
+func TestSupportedResourceTypes(t *testing.T) {
+	resources := provider.Provider("dev")().ResourcesMap
+	for _, resourceType := range import_blocks.SupportedResourceTypes() {
+		r, ok := resources[resourceType]
+		require.True(t, ok, resourceType)
+		assert.NotNil(t, r.Importer, resourceType)
+	}
+}
+
+// TestUndiscoveredResourceTypes lists the resource types that must be imported manually, so that a new resource type
+// is either discovered by the data source or knowingly added to this list.
+func TestUndiscoveredResourceTypes(t *testing.T) {
+	assert.Equal(t, []string{
+		"harness_platform_apikey",
+		"harness_platform_ccm_filters",
+		"harness_platform_db_instance",
+		"harness_platform_db_schema",
+		"harness_platform_delegatetoken",
+		"harness_platform_environment_clusters_mapping",
+		"harness_platform_environment_group",
+		"harness_platform_environment_service_overrides",
+		"harness_platform_feature_flag",
+		"harness_platform_feature_flag_target",
+		"harness_platform_feature_flag_target_group",
+		"harness_platform_file_store_file",
+		"harness_platform_file_store_folder",
+		"harness_platform_filters",
+		"harness_platform_gitops_agent",
+		"harness_platform_gitops_app_project",
+		"harness_platform_gitops_app_project_mapping",
+		"harness_platform_gitops_applications",
+		"harness_platform_gitops_cluster",
+		"harness_platform_gitops_gnupg",
+		"harness_platform_gitops_repo_cert",
+		"harness_platform_gitops_repo_cred",
+		"harness_platform_gitops_repository",
+		"harness_platform_gitx_webhook",
+		"harness_platform_iacm_default_pipeline",
+		"harness_platform_infra_module",
+		"harness_platform_infra_variable_set",
+		"harness_platform_manual_freeze",
+		"harness_platform_monitored_service",
+		"harness_platform_notification_rule",
+		"harness_platform_overrides",
+		"harness_platform_pipeline_filters",
+		"harness_platform_policy",
+		"harness_platform_policyset",
+		"harness_platform_provider",
+		"harness_platform_repo",
+		"harness_platform_repo_rule_branch",
+		"harness_platform_repo_webhook",
+		"harness_platform_role_assignments",
+		"harness_platform_service_overrides_v2",
+		"harness_platform_slo",
+		"harness_platform_template_filters",
+		"harness_platform_token",
+		"harness_platform_user",
+		"harness_platform_workspace",
+	}, import_blocks.UndiscoveredResourceTypes(provider.Provider("dev")().ResourcesMap))
+}
+
+func TestSetUndiscoveredResourceTypes(t *testing.T) {
+	r := import_blocks.DataSourceImportBlocks()
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil }
+	import_blocks.SetUndiscoveredResourceTypes(r, map[string]*schema.Resource{
+		"harness_platform_service": {Importer: helpers.MultiLevelResourceImporter},
+		"harness_platform_other":   {Importer: helpers.MultiLevelResourceImporter},
+		"harness_platform_nothing": {},
+	})
+
+	diags := r.ReadContext(context.Background(), schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{}), nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "1 resource types were not discovered", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "must be imported manually: harness_platform_other.")
+
+	// The warning is only emitted when all the supported resource types are discovered.
+	diags = r.ReadContext(context.Background(), schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"resource_types": []interface{}{"harness_platform_service"},
+	}), nil)
+	assert.Empty(t, diags)
+}
+
+func TestDataSourceImportBlocks(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	server.SeedProject(t, "org", "project")
+	server.Create(t, secret.ResourceSecretText(), map[string]interface{}{
+		"identifier":                "secret",
+		"name":                      "Secret",
+		"org_id":                    "org",
+		"project_id":                "project",
+		"secret_manager_identifier": "harnessSecretManager",
+		"value_type":                "Inline",
+		"value":                     "value",
+	})
+	server.Create(t, connector.ResourceConnectorDatadog(), map[string]interface{}{
+		"identifier":          "datadog",
+		"name":                "Datadog",
+		"org_id":              "org",
+		"project_id":          "project",
+		"url":                 "https://datadoghq.com",
+		"application_key_ref": "secret",
+		"api_key_ref":         "secret",
+	})
+	server.Create(t, service.ResourceService(), map[string]interface{}{"identifier": "service", "name": "Service", "org_id": "org", "project_id": "project"})
+	server.Create(t, pipeline.ResourcePipeline(), map[string]interface{}{
+		"identifier": "test",
+		"name":       "Test",
+		"org_id":     "org",
+		"project_id": "project",
+		"yaml":       pipelineYaml,
+	})
+	server.Create(t, input_set.ResourceInputSet(), map[string]interface{}{
+		"identifier":  "input",
+		"name":        "Input",
+		"org_id":      "org",
+		"project_id":  "project",
+		"pipeline_id": "test",
+		"yaml":        "inputSet:\n  name: Input\n  identifier: input\n  pipeline:\n    identifier: test\n",
+	})
+	server.Create(t, input_set.ResourceOverlayInputSet(), map[string]interface{}{
+		"identifier":           "overlay",
+		"name":                 "Overlay",
+		"org_id":               "org",
+		"project_id":           "project",
+		"pipeline_id":          "test",
+		"input_set_references": []interface{}{"input"},
+	})
+
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		r := import_blocks.DataSourceImportBlocks()
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		diags := r.ReadContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+		return d
+	}
+
+	d := read(map[string]interface{}{
+		"org_id":     "org",
+		"project_id": "project",
+		"resource_types": []interface{}{
+			"harness_platform_connector_datadog",
+			"harness_platform_secret_text",
+			"harness_platform_service",
+			"harness_platform_pipeline",
+		},
+	})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"resource_type": "harness_platform_connector_datadog",
+			"identifier":    "datadog",
+			"name":          "Datadog",
+			"address":       "harness_platform_connector_datadog.datadog",
+			"import_id":     "org/project/datadog",
+		},
+		map[string]interface{}{
+			"resource_type": "harness_platform_secret_text",
+			"identifier":    "secret",
+			"name":          "Secret",
+			"address":       "harness_platform_secret_text.secret",
+			"import_id":     "org/project/secret",
+		},
+		map[string]interface{}{
+			"resource_type": "harness_platform_service",
+			"identifier":    "service",
+			"name":          "Service",
+			"address":       "harness_platform_service.service",
+			"import_id":     "org/project/service",
+		},
+		map[string]interface{}{
+			"resource_type": "harness_platform_pipeline",
+			"identifier":    "test",
+			"name":          "Test",
+			"address":       "harness_platform_pipeline.test",
+			"import_id":     "org/project/test",
+		},
+	}, d.Get("resources"))
+	assert.Contains(t, d.Get("content"), "import {\n  to = harness_platform_service.service\n  id = \"org/project/service\"\n}\n")
+
+	// The input sets are discovered in the pipelines of the project.
+	d = read(map[string]interface{}{
+		"org_id":         "org",
+		"project_id":     "project",
+		"resource_types": []interface{}{"harness_platform_input_set", "harness_platform_overlay_input_set"},
+	})
+	assert.Equal(t, "import {\n  to = harness_platform_input_set.input\n  id = \"org/project/test/input\"\n}\n\n"+
+		"import {\n  to = harness_platform_overlay_input_set.overlay\n  id = \"org/project/test/overlay\"\n}\n", d.Get("content"))
+
+	// Projects are discovered in their organization and organizations in the account.
+	d = read(map[string]interface{}{"org_id": "org", "resource_types": []interface{}{"harness_platform_project", "harness_platform_service"}})
+	assert.Equal(t, "import {\n  to = harness_platform_project.project\n  id = \"org/project\"\n}\n", d.Get("content"))
+
+	d = read(map[string]interface{}{"resource_types": []interface{}{"harness_platform_organization"}})
+	assert.Contains(t, d.Get("content"), "to = harness_platform_organization.org\n  id = \"org\"")
+	assert.Contains(t, d.Get("content"), "to = harness_platform_organization.default\n")
+}
@@ -42,6 +42,7 @@ func ResourceProject() *schema.Resource {
 	}
 
 	helpers.SetOrgLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Org)
 
 	return resource
 }
@@ -37,10 +37,10 @@ func testAccDataSourceProvider(id string, name string) string {
 			spec {
 				type = "BITBUCKET_SERVER"
 				domain              = "https://example.com"
-				secret_manager_ref  = "secret-ref"
+				secret_manager_ref  = "secret_ref"
 				delegate_selectors  = ["delegate-1", "delegate-2"]
 				client_id           = "client-id"
-				client_secret_ref   = "client-secret-ref"
+				client_secret_ref   = "client_secret_ref"
 			}
 		}
 
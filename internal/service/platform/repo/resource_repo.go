@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -24,6 +25,13 @@ func ResourceRepo() *schema.Resource {
 		DeleteContext: resourceRepoDelete,
 		Importer:      helpers.MultiLevelResourceImporter,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: createSchema(),
 	}
 
@@ -123,6 +131,8 @@ func resourceRepoCreateOrUpdate(
 
 	// If import is in progress, wait for it to complete
 	if repo.Importing {
+		// Keep the repository in the state so that it is tainted rather than orphaned if the import does not complete in time.
+		d.SetId(repo.Identifier)
 		if err := waitForImportCompletion(ctx, c.RepositoryApi, repo.Identifier, c.AccountId, orgID, projectID); err != nil {
 			return diag.FromErr(err)
 		}
@@ -242,7 +252,7 @@ func waitForImportCompletion(ctx context.Context, api *code.RepositoryApiService
 			// Sleep for 5 seconds
 		case <-ctx.Done():
 			// Context canceled, return with error
-			return ctx.Err()
+			return fmt.Errorf("timeout waiting for the import of repository %s to complete: %w", importID, ctx.Err())
 		}
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -81,6 +82,15 @@ func resourceSecretCreateOrUpdateBase(ctx context.Context, d *schema.ResourceDat
 	return resp.Data.Secret, nil
 }
 
+// getWriteOnlyValue returns the value of a write-only string attribute, which is only available in the configuration.
+func getWriteOnlyValue(d *schema.ResourceData, field string) string {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(field))
+	if diags.HasError() || !v.Type().Equals(cty.String) || v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+	return v.AsString()
+}
+
 func buildField(d *schema.ResourceData, field string) optional.String {
 	if arr, ok := d.GetOk(field); ok {
 		return optional.NewString(arr.(string))
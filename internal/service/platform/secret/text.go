@@ -33,10 +33,25 @@ func ResourceSecretText() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"Reference", "Inline", "CustomSecretManagerValues"}, false),
 			},
 			"value": {
-				Description: "Value of the Secret",
-				Sensitive:   true,
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:   "Value of the Secret",
+				Sensitive:     true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"value_wo"},
+			},
+			"value_wo": {
+				Description:   "Write-only value of the Secret, which is never stored in the plan or state. Requires Terraform 1.11 or later. As its changes can't be detected, increment `value_wo_version` to update the secret.",
+				Sensitive:     true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"value"},
+			},
+			"value_wo_version": {
+				Description:  "Version of `value_wo`. Changing it updates the secret with the current value of `value_wo`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
 			},
 			"additional_metadata": {
 				Description: "Additional Metadata for the Secret",
@@ -64,6 +79,7 @@ func ResourceSecretText() *schema.Resource {
 		},
 	}
 	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Multi)
 
 	return resource
 }
@@ -114,6 +130,10 @@ func buildSecretText(d *schema.ResourceData) *nextgen.Secret {
 		secret.Text.Value = attr.(string)
 	}
 
+	if attr := getWriteOnlyValue(d, "value_wo"); attr != "" {
+		secret.Text.Value = attr
+	}
+
 	if attr, ok := d.GetOk("additional_metadata"); ok {
 		secret.Text.AdditionalMetadata = readAdditionalMetadata(attr)
 	}
@@ -127,7 +147,8 @@ func readSecretText(d *schema.ResourceData, secret *nextgen.Secret) error {
 	}
 	d.Set("secret_manager_identifier", secret.Text.SecretManagerIdentifier)
 	d.Set("value_type", secret.Text.ValueType)
-	if secret.Text.ValueType == "Reference" {
+	// The value is not stored when it is set with value_wo, which would otherwise show it as removed in the plans.
+	if _, ok := d.GetOk("value_wo_version"); secret.Text.ValueType == "Reference" && !ok {
 		d.Set("value", secret.Text.Value)
 	}
 	if secret.Text.AdditionalMetadata.Values != nil {
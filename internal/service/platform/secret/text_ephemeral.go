@@ -0,0 +1,144 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &ephemeralSecretText{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ephemeralSecretText{}
+)
+
+// NewEphemeralSecretText returns the ephemeral secret text resource, which reads a secret of type secret text
+// without storing its value in the state.
+func NewEphemeralSecretText() ephemeral.EphemeralResource {
+	return &ephemeralSecretText{}
+}
+
+type ephemeralSecretText struct {
+	session *internal.Session
+}
+
+type ephemeralSecretTextModel struct {
+	Identifier              types.String `tfsdk:"identifier"`
+	OrgId                   types.String `tfsdk:"org_id"`
+	ProjectId               types.String `tfsdk:"project_id"`
+	Name                    types.String `tfsdk:"name"`
+	Description             types.String `tfsdk:"description"`
+	Tags                    types.Set    `tfsdk:"tags"`
+	SecretManagerIdentifier types.String `tfsdk:"secret_manager_identifier"`
+	ValueType               types.String `tfsdk:"value_type"`
+	Value                   types.String `tfsdk:"value"`
+}
+
+func (r *ephemeralSecretText) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platform_secret_text"
+}
+
+func (r *ephemeralSecretText) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ephemeral resource for reading a secret of type secret text without storing it in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Description: "Unique identifier of the resource.",
+				Required:    true,
+			},
+			"org_id": schema.StringAttribute{
+				Description: "Unique identifier of the organization.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Unique identifier of the project.",
+				Optional:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the resource.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the resource.",
+				Computed:    true,
+			},
+			"tags": schema.SetAttribute{
+				Description: "Tags to associate with the resource.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"secret_manager_identifier": schema.StringAttribute{
+				Description: "Identifier of the Secret Manager used to manage the secret.",
+				Computed:    true,
+			},
+			"value_type": schema.StringAttribute{
+				Description: "This has details to specify if the secret value is Inline or Reference.",
+				Computed:    true,
+			},
+			"value": schema.StringAttribute{
+				Description: "Value of the Secret. The Harness API only returns the value of `Reference` secrets, it is null for `Inline` secrets.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *ephemeralSecretText) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	session, ok := req.ProviderData.(*internal.Session)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *internal.Session, got %T.", req.ProviderData))
+		return
+	}
+	r.session = session
+}
+
+func (r *ephemeralSecretText) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralSecretTextModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, ctx := r.session.GetPlatformClientWithContext(ctx)
+	secretResp, httpResp, err := c.SecretsApi.GetSecretV2(ctx, data.Identifier.ValueString(), c.AccountId, &nextgen.SecretsApiGetSecretV2Opts{
+		OrgIdentifier:     helpers.OptionalString(data.OrgId.ValueString()),
+		ProjectIdentifier: helpers.OptionalString(data.ProjectId.ValueString()),
+	})
+	if err != nil {
+		helpers.AddApiError(&resp.Diagnostics, err, httpResp, helpers.ConfigAttributes(req.Config)...)
+		return
+	}
+	if secretResp.Data == nil || secretResp.Data.Secret == nil {
+		resp.Diagnostics.AddError("Secret not found", fmt.Sprintf("The secret %s does not exist.", data.Identifier.ValueString()))
+		return
+	}
+
+	secret := secretResp.Data.Secret
+	if secret.Type_ != nextgen.SecretTypes.SecretText || secret.Text == nil {
+		resp.Diagnostics.AddError("Unexpected secret type", fmt.Sprintf("expected secret to be of type %s, but got %s", nextgen.SecretTypes.SecretText, secret.Type_))
+		return
+	}
+
+	tags, diags := types.SetValueFrom(ctx, types.StringType, helpers.FlattenTags(secret.Tags))
+	resp.Diagnostics.Append(diags...)
+	data.Name = types.StringValue(secret.Name)
+	data.Description = types.StringValue(secret.Description)
+	data.Tags = tags
+	data.SecretManagerIdentifier = types.StringValue(secret.Text.SecretManagerIdentifier)
+	data.ValueType = types.StringValue(string(secret.Text.ValueType))
+	data.Value = types.StringNull()
+	if secret.Text.ValueType == nextgen.SecretTextValueTypes.Reference {
+		data.Value = types.StringValue(secret.Text.Value)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
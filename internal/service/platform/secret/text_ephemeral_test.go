@@ -0,0 +1,52 @@
+package secret_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/harness/terraform-provider-harness/internal/service/platform/secret"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralSecretText(t *testing.T) {
+	server := fakeserver.New(t)
+	session := server.Session(t)
+	ctx := context.Background()
+
+	r := secret.ResourceSecretText()
+	for id, valueType := range map[string]string{"inline": "Inline", "reference": "Reference"} {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"identifier":                id,
+			"name":                      id,
+			"secret_manager_identifier": "azureSecretManager",
+			"value_type":                valueType,
+			"value":                     "value",
+		})
+		diags := r.CreateContext(ctx, d, session)
+		require.False(t, diags.HasError(), diags)
+	}
+
+	providerServer := server.ProviderServer(t)
+	open := func(id string) (string, string, bool) {
+		result, resp := fakeserver.OpenEphemeralResource(t, providerServer, "harness_platform_secret_text", map[string]string{"identifier": id})
+		require.Empty(t, resp.Diagnostics)
+		var valueType, value string
+		require.NoError(t, result["value_type"].As(&valueType))
+		require.NoError(t, result["value"].As(&value))
+		return valueType, value, result["value"].IsNull()
+	}
+
+	valueType, value, _ := open("reference")
+	require.Equal(t, "Reference", valueType)
+	require.Equal(t, "value", value)
+
+	// The API does not return the value of inline secrets.
+	valueType, _, isNull := open("inline")
+	require.Equal(t, "Inline", valueType)
+	require.True(t, isNull)
+
+	_, resp := fakeserver.OpenEphemeralResource(t, providerServer, "harness_platform_secret_text", map[string]string{"identifier": "missing"})
+	require.NotEmpty(t, resp.Diagnostics)
+}
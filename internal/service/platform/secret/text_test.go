@@ -60,6 +60,38 @@ func TestAccSecretText_inline(t *testing.T) {
 	})
 }
 
+func TestAccSecretText_writeOnly(t *testing.T) {
+
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	secretValue := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	resourceName := "harness_platform_secret_text.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccSecretDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecret_text_writeOnly(id, secretValue, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "value_wo_version", "1"),
+					resource.TestCheckNoResourceAttr(resourceName, "value"),
+					resource.TestCheckNoResourceAttr(resourceName, "value_wo"),
+				),
+			},
+			{
+				Config: testAccResourceSecret_text_writeOnly(id, secretValue+"updated", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "value_wo_version", "2"),
+					resource.TestCheckNoResourceAttr(resourceName, "value_wo"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceSecretText_reference(t *testing.T) {
 	t.Skip()
 	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
@@ -316,6 +348,19 @@ func testAccResourceSecret_text_inline(id string, name string, secretValue strin
 `, id, name, secretValue)
 }
 
+func testAccResourceSecret_text_writeOnly(id string, secretValue string, version int) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_secret_text" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			secret_manager_identifier = "harnessSecretManager"
+			value_type = "Inline"
+			value_wo = "%[2]s"
+			value_wo_version = %[3]d
+		}
+`, id, secretValue, version)
+}
+
 func testAccResourceSecret_text_reference(id string, name string, secretValue string, secretManagerIdentifier string) string {
 	return fmt.Sprintf(`
 		resource "harness_platform_secret_text" "test" {
@@ -0,0 +1,211 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+)
+
+// ephemeralTokenValidity is how long the token is valid when valid_to is not set, so that it expires on its own
+// when it can't be revoked.
+const ephemeralTokenValidity = 24 * time.Hour
+
+// privateTokenKey is the key of the private data identifying the token to revoke.
+const privateTokenKey = "token"
+
+var (
+	_ ephemeral.EphemeralResource              = &ephemeralToken{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ephemeralToken{}
+	_ ephemeral.EphemeralResourceWithClose     = &ephemeralToken{}
+)
+
+// NewEphemeralToken returns the ephemeral token resource, which mints a token of an API key for the duration of a
+// Terraform run and revokes it afterwards, so that the token is never stored in the state.
+func NewEphemeralToken() ephemeral.EphemeralResource {
+	return &ephemeralToken{}
+}
+
+type ephemeralToken struct {
+	session *internal.Session
+}
+
+type ephemeralTokenModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	Name       types.String `tfsdk:"name"`
+	ApiKeyId   types.String `tfsdk:"apikey_id"`
+	ApiKeyType types.String `tfsdk:"apikey_type"`
+	ParentId   types.String `tfsdk:"parent_id"`
+	OrgId      types.String `tfsdk:"org_id"`
+	ProjectId  types.String `tfsdk:"project_id"`
+	ValidTo    types.Int64  `tfsdk:"valid_to"`
+	Value      types.String `tfsdk:"value"`
+}
+
+// ephemeralTokenPrivate identifies the token to revoke when the ephemeral resource is closed.
+type ephemeralTokenPrivate struct {
+	Identifier string `json:"identifier"`
+	ApiKeyId   string `json:"apikey_id"`
+	ApiKeyType string `json:"apikey_type"`
+	ParentId   string `json:"parent_id"`
+	OrgId      string `json:"org_id,omitempty"`
+	ProjectId  string `json:"project_id,omitempty"`
+}
+
+func (r *ephemeralToken) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platform_token"
+}
+
+func (r *ephemeralToken) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ephemeral resource for minting a token of an API key. The token is created when Terraform needs it and is revoked at the end of the run, and its value is never stored in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Description: "Identifier of the token. Defaults to a unique identifier starting with `terraform_`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the token. Defaults to the identifier.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"apikey_id": schema.StringAttribute{
+				Description: "Identifier of the API Key",
+				Required:    true,
+			},
+			"apikey_type": schema.StringAttribute{
+				Description: "Type of the API Key. Valid values are `USER` and `SERVICE_ACCOUNT`.",
+				Required:    true,
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "Parent Entity Identifier of the API Key",
+				Required:    true,
+			},
+			"org_id": schema.StringAttribute{
+				Description: "Unique identifier of the organization.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Unique identifier of the project.",
+				Optional:    true,
+			},
+			"valid_to": schema.Int64Attribute{
+				Description: "This is the time till which the Token is valid. The time is in milliseconds. Defaults to one day after the token is created, so that the token expires even when it can't be revoked.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"value": schema.StringAttribute{
+				Description: "Value of the Token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *ephemeralToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	session, ok := req.ProviderData.(*internal.Session)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *internal.Session, got %T.", req.ProviderData))
+		return
+	}
+	r.session = session
+}
+
+func (r *ephemeralToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if t := data.ApiKeyType.ValueString(); t != "USER" && t != "SERVICE_ACCOUNT" {
+		resp.Diagnostics.AddAttributeError(path.Root("apikey_type"), "Invalid API key type", fmt.Sprintf("expected apikey_type to be one of [USER SERVICE_ACCOUNT], got %s", t))
+		return
+	}
+
+	if data.Identifier.IsNull() {
+		data.Identifier = types.StringValue(id.PrefixedUniqueId("terraform_"))
+	}
+	if data.Name.IsNull() {
+		data.Name = data.Identifier
+	}
+	now := time.Now()
+	if data.ValidTo.IsNull() {
+		data.ValidTo = types.Int64Value(now.Add(ephemeralTokenValidity).UnixMilli())
+	}
+
+	c, ctx := r.session.GetPlatformClientWithContext(ctx)
+	token := &nextgen.Token{
+		Identifier:        data.Identifier.ValueString(),
+		Name:              data.Name.ValueString(),
+		ApiKeyIdentifier:  data.ApiKeyId.ValueString(),
+		ApiKeyType:        data.ApiKeyType.ValueString(),
+		ParentIdentifier:  data.ParentId.ValueString(),
+		AccountIdentifier: c.AccountId,
+		OrgIdentifier:     data.OrgId.ValueString(),
+		ProjectIdentifier: data.ProjectId.ValueString(),
+		ValidFrom:         now.UnixMilli(),
+		ValidTo:           data.ValidTo.ValueInt64(),
+	}
+
+	tokenResp, httpResp, err := c.TokenApi.CreateToken(ctx, c.AccountId, &nextgen.TokenApiCreateTokenOpts{Body: optional.NewInterface(token)})
+	if err != nil {
+		helpers.AddApiError(&resp.Diagnostics, err, httpResp, helpers.ConfigAttributes(req.Config)...)
+		return
+	}
+	data.Value = types.StringValue(tokenResp.Data)
+
+	private, err := json.Marshal(ephemeralTokenPrivate{
+		Identifier: token.Identifier,
+		ApiKeyId:   token.ApiKeyIdentifier,
+		ApiKeyType: token.ApiKeyType,
+		ParentId:   token.ParentIdentifier,
+		OrgId:      token.OrgIdentifier,
+		ProjectId:  token.ProjectIdentifier,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error saving the token identifiers", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateTokenKey, private)...)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close revokes the token minted by Open.
+func (r *ephemeralToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	data, diags := req.Private.GetKey(ctx, privateTokenKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || data == nil {
+		return
+	}
+
+	var token ephemeralTokenPrivate
+	if err := json.Unmarshal(data, &token); err != nil {
+		resp.Diagnostics.AddError("Error reading the token identifiers", err.Error())
+		return
+	}
+
+	c, ctx := r.session.GetPlatformClientWithContext(ctx)
+	_, httpResp, err := c.TokenApi.DeleteToken(ctx, token.Identifier, c.AccountId, token.ApiKeyType, token.ParentId, token.ApiKeyId, &nextgen.TokenApiDeleteTokenOpts{
+		OrgIdentifier:     helpers.OptionalString(token.OrgId),
+		ProjectIdentifier: helpers.OptionalString(token.ProjectId),
+	})
+	if err != nil {
+		helpers.AddApiError(&resp.Diagnostics, err, httpResp)
+	}
+}
@@ -0,0 +1,51 @@
+package token_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal/acctest/fakeserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralToken(t *testing.T) {
+	server := fakeserver.New(t)
+	providerServer := server.ProviderServer(t)
+
+	result, resp := fakeserver.OpenEphemeralResource(t, providerServer, "harness_platform_token", map[string]string{
+		"apikey_id":   "apikey",
+		"apikey_type": "SERVICE_ACCOUNT",
+		"parent_id":   "service_account",
+	})
+	require.Empty(t, resp.Diagnostics)
+
+	var id, value string
+	require.NoError(t, result["identifier"].As(&id))
+	require.NoError(t, result["value"].As(&value))
+	require.True(t, strings.HasPrefix(id, "terraform_"), id)
+	require.Equal(t, "pat."+fakeserver.AccountId+"."+id+".fake", value)
+	require.True(t, server.Exists("token", "", "", id))
+
+	// The token is revoked when the ephemeral resource is closed.
+	closeResp, err := providerServer.CloseEphemeralResource(context.Background(), &tfprotov5.CloseEphemeralResourceRequest{
+		TypeName: "harness_platform_token",
+		Private:  resp.Private,
+	})
+	require.NoError(t, err)
+	require.Empty(t, closeResp.Diagnostics)
+	require.False(t, server.Exists("token", "", "", id))
+}
+
+func TestEphemeralToken_invalidApiKeyType(t *testing.T) {
+	server := fakeserver.New(t)
+
+	_, resp := fakeserver.OpenEphemeralResource(t, server.ProviderServer(t), "harness_platform_token", map[string]string{
+		"apikey_id":   "apikey",
+		"apikey_type": "ACCOUNT",
+		"parent_id":   "service_account",
+	})
+	require.Len(t, resp.Diagnostics, 1)
+	require.Equal(t, "Invalid API key type", resp.Diagnostics[0].Summary)
+}
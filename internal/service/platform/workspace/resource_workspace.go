@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/harness/terraform-provider-harness/internal/utils"
 
@@ -25,6 +26,13 @@ func ResourceWorkspace() *schema.Resource {
 		UpdateContext: resourceWorkspaceUpdate,
 		Importer:      helpers.ProjectResourceImporter,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"identifier": {
 				Description: "Identifier of the Workspace.",
@@ -212,6 +220,7 @@ func ResourceWorkspace() *schema.Resource {
 	}
 
 	helpers.SetProjectLevelResourceSchema(resource.Schema)
+	helpers.SetScopeDefaults(resource, helpers.ScopeLevels.Project)
 	return resource
 }
 
@@ -2,6 +2,9 @@ package internal
 
 import (
 	"context"
+	"net/http"
+	"sync"
+
 	"github.com/harness/harness-go-sdk/harness/cd"
 	"github.com/harness/harness-go-sdk/harness/chaos"
 	"github.com/harness/harness-go-sdk/harness/code"
@@ -13,15 +16,23 @@ import (
 )
 
 type Session struct {
-	AccountId   string
-	Endpoint    string
-	CDClient    *cd.ApiClient
-	PLClient    *nextgen.APIClient
-	DBOpsClient *dbops.APIClient
-	Client      *openapi_client_nextgen.APIClient
-	CodeClient  *code.APIClient
-	ChaosClient *chaos.APIClient
-	HARClient  	*har.APIClient
+	AccountId        string
+	Endpoint         string
+	DefaultOrgId     string
+	DefaultProjectId string
+	DefaultTags      map[string]string
+	VerifyReferences bool
+	VerifyYaml       bool
+	// ReferenceLookups caches whether the connectors and secrets verified with VerifyReferences are missing.
+	ReferenceLookups sync.Map
+	CDClient         *cd.ApiClient
+	PLClient         *nextgen.APIClient
+	DBOpsClient      *dbops.APIClient
+	Client           *openapi_client_nextgen.APIClient
+	CodeClient       *code.APIClient
+	ChaosClient      *chaos.APIClient
+	HARClient        *har.APIClient
+	PMHTTPClient     *http.Client
 }
 
 func (s *Session) GetPlatformClient() (*nextgen.APIClient, context.Context) {
@@ -45,9 +56,11 @@ func (s *Session) GetClientWithContext(ctx context.Context) (*openapi_client_nex
 }
 
 func (s *Session) GetPolicyManagementClient() *policymgmt.APIClient {
-	c := policymgmt.NewAPIClient(
-		policymgmt.NewConfiguration(),
-	)
+	cfg := policymgmt.NewConfiguration()
+	if s.PMHTTPClient != nil {
+		cfg.HTTPClient = s.PMHTTPClient
+	}
+	c := policymgmt.NewAPIClient(cfg)
 	c.ChangeBasePath(s.Endpoint + "/pm")
 	return c
 }
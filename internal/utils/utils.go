@@ -52,7 +52,10 @@ func ExpandTags(tags []interface{}) map[string]string {
 	result := map[string]string{}
 
 	for _, tag := range tags {
-		parts := strings.Split(tag.(string), ":")
+		parts := strings.SplitN(tag.(string), ":", 2)
+		if len(parts) == 1 {
+			parts = append(parts, "")
+		}
 		result[parts[0]] = parts[1]
 	}
 
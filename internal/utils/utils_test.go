@@ -26,3 +26,7 @@ func TestGetConflictsWithSlice(t *testing.T) {
 
 	require.Len(t, source, expectedItemCount)
 }
+
+func TestExpandTags(t *testing.T) {
+	require.Equal(t, map[string]string{"url": "https://harness.io:443", "empty": ""}, utils.ExpandTags([]interface{}{"url:https://harness.io:443", "empty"}))
+}